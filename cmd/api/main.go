@@ -1,14 +1,27 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	_ "github.com/lib/pq"
 	"github.com/zahidhasann88/job-board-api/internal/api"
 	"github.com/zahidhasann88/job-board-api/internal/config"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/engagement"
+	"github.com/zahidhasann88/job-board-api/internal/matching"
 	"github.com/zahidhasann88/job-board-api/internal/repository/postgres"
+	"github.com/zahidhasann88/job-board-api/internal/scheduler"
+	"github.com/zahidhasann88/job-board-api/internal/screening"
 	"github.com/zahidhasann88/job-board-api/internal/service"
+	"github.com/zahidhasann88/job-board-api/internal/similarity"
+	"github.com/zahidhasann88/job-board-api/internal/skills"
+	"github.com/zahidhasann88/job-board-api/internal/tasks"
 	"github.com/zahidhasann88/job-board-api/pkg/logger"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/template"
 )
 
 func main() {
@@ -29,18 +42,98 @@ func main() {
 	defer db.Close()
 
 	// Initialize repositories
-	userRepo := postgres.NewUserRepository(db)
-	jobRepo := postgres.NewJobRepository(db)
+	userStore := postgres.NewUserRepository(db)
+	jobStore := postgres.NewJobRepository(db)
+	jobRevisionRepo := postgres.NewJobRevisionRepository(db)
+	jobTemplateRepo := postgres.NewJobTemplateRepository(db)
+	applyClickRepo := postgres.NewApplyClickRepository(db)
+	engagementRepo := postgres.NewEngagementRepository(db)
 	applicationRepo := postgres.NewApplicationRepository(db)
+	notificationRepo := postgres.NewNotificationRepository(db)
+	taskRepo := postgres.NewTaskRepository(db)
+
+	// Keep the similar jobs index in step with job writes
+	similarJobs := similarity.NewIndex()
+	jobRepo := similarity.NewIndexedJobRepository(jobStore, similarJobs, l)
+	userRepo := similarity.NewIndexedUserRepository(userStore, jobStore, similarJobs, l)
+	if err := jobRepo.Load(context.Background()); err != nil {
+		log.Fatalf("Failed to build similar jobs index: %v", err)
+	}
+
+	// Load fraud screening rules
+	screeningCfg, err := screening.LoadConfig(cfg.ScreeningRulesPath)
+	if err != nil {
+		log.Fatalf("Failed to load screening rules: %v", err)
+	}
+	screener, err := screeningCfg.Build(jobStore.KnownBadTexts)
+	if err != nil {
+		log.Fatalf("Failed to build screening pipeline: %v", err)
+	}
+
+	// Load the skill taxonomy
+	taxonomyCfg, err := skills.LoadConfig(cfg.SkillTaxonomyPath)
+	if err != nil {
+		log.Fatalf("Failed to load skill taxonomy: %v", err)
+	}
+	taxonomy, err := taxonomyCfg.Build()
+	if err != nil {
+		log.Fatalf("Failed to build skill taxonomy: %v", err)
+	}
 
 	// Initialize services
-	userService := service.NewUserService(userRepo, cfg.JWTSecret)
-	jobService := service.NewJobService(jobRepo)
-	applicationService := service.NewApplicationService(applicationRepo)
+	notificationService := service.NewNotificationService(notificationRepo)
+	userService := service.NewUserService(userRepo, taxonomy, cfg.JWTSecret, cfg.TrackingSecret, cfg.DeletedRetentionDays)
+	jobService := service.NewJobService(jobRepo, jobRevisionRepo, applyClickRepo, userRepo, notificationService, screener, taxonomy, similarJobs, service.JobPolicy{
+		ModerationMode:     cfg.JobModerationMode,
+		NewCompanyDays:     cfg.ModerationNewCompanyDays,
+		DuplicatePolicy:    cfg.DuplicateJobPolicy,
+		DuplicateThreshold: cfg.DuplicateJobThreshold,
+		ExpiryReminderDays: cfg.ExpiryReminderDays,
+		RenewalDays:        cfg.JobRenewalDays,
+		RetentionDays:      cfg.DeletedRetentionDays,
+		TrackingSecret:     cfg.TrackingSecret,
+		MatchWeights: matching.Weights{
+			Skills:       cfg.MatchWeightSkills,
+			Experience:   cfg.MatchWeightExperience,
+			Location:     cfg.MatchWeightLocation,
+			Completeness: cfg.MatchWeightCompleteness,
+			History:      cfg.MatchWeightHistory,
+		},
+	})
+	jobTemplateService := service.NewJobTemplateService(jobTemplateRepo, jobRepo)
+	filledNotice, err := template.New("position_filled").Parse(cfg.PositionFilledTemplate)
+	if err != nil {
+		log.Fatalf("Invalid position filled template: %v", err)
+	}
+	applicationService := service.NewApplicationService(applicationRepo, jobService, notificationService, filledNotice)
+
+	// Start background jobs; they stop on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	scheduler.NewScheduler(jobService, userService, l, cfg.SchedulerInterval).Start(ctx)
+
+	// The recorder outlives the server so events from requests still in
+	// flight at shutdown are written too
+	recordCtx, stopRecording := context.WithCancel(context.Background())
+	recorder := engagement.NewRecorder(engagementRepo, l, cfg.EngagementBufferSize, cfg.EngagementFlushInterval)
+	recorder.Start(recordCtx)
+	engagementService := service.NewEngagementService(engagementRepo, recorder, cfg.TrackingSecret)
+
+	taskRunner := tasks.NewRunner(taskRepo, l, cfg.TaskWorkers)
+	taskRunner.Register(domain.TaskBulkCreateJobs, jobService.RunBulkCreate)
+	taskRunner.Register(domain.TaskBulkJobStatus, jobService.RunBulkStatus)
+	taskRunner.Register(domain.TaskBulkCloseJobs, jobService.RunBulkClose)
+	if err := taskRunner.Start(ctx); err != nil {
+		log.Fatalf("Failed to start task runner: %v", err)
+	}
 
 	// Initialize and start the server
-	server := api.NewServer(cfg, l, userService, jobService, applicationService)
-	if err := server.Run(); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	server := api.NewServer(cfg, l, userService, jobService, jobTemplateService, applicationService, notificationService, engagementService, taskRunner, taxonomy)
+	err = server.Run(ctx)
+
+	stopRecording()
+	recorder.Wait()
+	if err != nil {
+		log.Fatalf("Failed to run server: %v", err)
 	}
 }
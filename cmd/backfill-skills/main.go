@@ -0,0 +1,54 @@
+// Command backfill-skills rewrites the skills already stored on jobs,
+// templates and user profiles with their canonical names from the skill
+// taxonomy. New writes are normalized by the API; this catches up the rows
+// saved before the taxonomy existed or changed. It is safe to run again.
+package main
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/lib/pq"
+	"github.com/zahidhasann88/job-board-api/internal/config"
+	"github.com/zahidhasann88/job-board-api/internal/repository/postgres"
+	"github.com/zahidhasann88/job-board-api/internal/skills"
+	"log"
+)
+
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	taxonomyCfg, err := skills.LoadConfig(cfg.SkillTaxonomyPath)
+	if err != nil {
+		log.Fatalf("Failed to load skill taxonomy: %v", err)
+	}
+	taxonomy, err := taxonomyCfg.Build()
+	if err != nil {
+		log.Fatalf("Failed to build skill taxonomy: %v", err)
+	}
+
+	db, err := sql.Open("postgres", cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	tables := []struct {
+		name      string
+		normalize func(context.Context, func([]string) []string) (int, error)
+	}{
+		{"jobs", postgres.NewJobRepository(db).NormalizeSkills},
+		{"job_templates", postgres.NewJobTemplateRepository(db).NormalizeSkills},
+		{"users", postgres.NewUserRepository(db).NormalizeSkills},
+	}
+	for _, table := range tables {
+		changed, err := table.normalize(ctx, taxonomy.Normalize)
+		if err != nil {
+			log.Fatalf("Failed to normalize skills of %s: %v", table.name, err)
+		}
+		log.Printf("Normalized skills of %d %s", changed, table.name)
+	}
+}
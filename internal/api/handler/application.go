@@ -1,26 +1,36 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/zahidhasann88/job-board-api/internal/domain"
 	"github.com/zahidhasann88/job-board-api/internal/service"
+	"github.com/zahidhasann88/job-board-api/pkg/validator"
 )
 
 type ApplicationHandler struct {
 	applicationService *service.ApplicationService
+	engagement         *service.EngagementService
+	customValidator    *validator.CustomValidator
 }
 
-func NewApplicationHandler(applicationService *service.ApplicationService) *ApplicationHandler {
-	return &ApplicationHandler{applicationService: applicationService}
+func NewApplicationHandler(applicationService *service.ApplicationService, engagement *service.EngagementService) *ApplicationHandler {
+	return &ApplicationHandler{
+		applicationService: applicationService,
+		engagement:         engagement,
+		customValidator:    validator.NewValidator(),
+	}
 }
 
 type CreateApplicationRequest struct {
 	JobID       uuid.UUID `json:"job_id" binding:"required"`
 	CoverLetter string    `json:"cover_letter"`
 	ResumeURL   string    `json:"resume_url" binding:"required"`
+	Source      string    `json:"source" binding:"max=50"`
 }
 
 func (h *ApplicationHandler) Create(c *gin.Context) {
@@ -37,12 +47,14 @@ func (h *ApplicationHandler) Create(c *gin.Context) {
 		CoverLetter: req.CoverLetter,
 		ResumeURL:   req.ResumeURL,
 		Status:      "pending",
+		Source:      req.Source,
 	}
 
 	if err := h.applicationService.Create(c.Request.Context(), application); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(applicationErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
+	h.engagement.Record(domain.EngagementApplyComplete, visitorID(c), application.JobID)
 
 	c.JSON(http.StatusCreated, application)
 }
@@ -56,4 +68,46 @@ func (h *ApplicationHandler) List(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, applications)
-}
\ No newline at end of file
+}
+
+func (h *ApplicationHandler) ChangeStatus(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid application ID"})
+		return
+	}
+
+	var req domain.UpdateApplicationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.customValidator.Validate(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	application, err := h.applicationService.ChangeStatus(c.Request.Context(), id, userID.(uuid.UUID), strings.ToLower(req.Status))
+	if err != nil {
+		c.JSON(applicationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, application)
+}
+
+// applicationErrorStatus maps application service errors to HTTP status codes.
+func applicationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrJobNotFound), errors.Is(err, service.ErrApplicationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrNotJobOwner):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrJobNotOpen), errors.Is(err, service.ErrDeadlinePassed),
+		errors.Is(err, service.ErrExternalApplication):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
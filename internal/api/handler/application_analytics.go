@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/service"
+)
+
+// Dashboard reports the recruiter's hiring pipeline over a date range, with
+// the previous period for comparison. interval is day, week or month and
+// group_by optionally splits it by job, location or job_type. format=csv
+// exports the time series.
+func (h *ApplicationHandler) Dashboard(c *gin.Context) {
+	from, to, err := dateRange(c, defaultReportDays)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	dashboard, err := h.applicationService.Dashboard(c.Request.Context(), userID.(uuid.UUID), domain.DashboardQuery{
+		From:     from,
+		To:       to,
+		Interval: c.Query("interval"),
+		GroupBy:  c.Query("group_by"),
+	})
+	if err != nil {
+		c.JSON(dashboardErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		writeDashboardCSV(c, dashboard)
+		return
+	}
+	c.JSON(http.StatusOK, dashboard)
+}
+
+func writeDashboardCSV(c *gin.Context, dashboard *domain.ApplicationDashboard) {
+	filename := fmt.Sprintf("applications-%s-%s.csv", dashboard.From, dashboard.To)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	header := []string{"period"}
+	if dashboard.GroupBy != "" {
+		header = append(header, dashboard.GroupBy, dashboard.GroupBy+"_label")
+	}
+	w.Write(append(header, "applications", "hires"))
+	for _, p := range dashboard.Series {
+		record := []string{p.Period}
+		if dashboard.GroupBy != "" {
+			record = append(record, p.Group, p.Label)
+		}
+		w.Write(append(record, strconv.Itoa(p.Applications), strconv.Itoa(p.Hires)))
+	}
+	w.Flush()
+}
+
+func dashboardErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidDateRange), errors.Is(err, service.ErrInvalidInterval),
+		errors.Is(err, service.ErrInvalidGroupBy):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
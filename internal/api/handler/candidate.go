@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/service"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+// SearchCandidates lets recruiters find job seekers beyond those who applied
+// to their jobs.
+func (h *UserHandler) SearchCandidates(c *gin.Context) {
+	search := domain.CandidateSearch{
+		Query:          c.Query("q"),
+		Skills:         c.QueryArray("skills"),
+		Certifications: c.QueryArray("certifications"),
+	}
+	if loc := c.Query("location"); loc != "" {
+		search.Location = &loc
+	}
+	var err error
+	if search.MinYears, err = queryYears(c, "min_years"); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid min_years", err.Error())
+		return
+	}
+	if search.MaxYears, err = queryYears(c, "max_years"); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid max_years", err.Error())
+		return
+	}
+	if v := c.Query("open_to_work"); v != "" {
+		open, err := strconv.ParseBool(v)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid open_to_work", err.Error())
+			return
+		}
+		search.OpenToWork = &open
+	}
+	page, pageSize := pagination(c)
+	userID, _ := c.Get("userID")
+
+	candidates, total, err := h.userService.SearchCandidates(c.Request.Context(), userID.(uuid.UUID), search, page, pageSize)
+	if err != nil {
+		response.Error(c, userErrorStatus(err), "Failed to search candidates", err.Error())
+		return
+	}
+
+	meta := response.Meta{
+		Total:     total,
+		Page:      page,
+		PageSize:  pageSize,
+		TotalPage: (total + pageSize - 1) / pageSize,
+	}
+	response.SuccessWithMeta(c, http.StatusOK, "Candidates retrieved", candidates, meta)
+}
+
+// ViewCandidate shows a recruiter the full profile of a job seeker and counts
+// it as a profile view.
+func (h *UserHandler) ViewCandidate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid user ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	profile, err := h.userService.ViewCandidate(c.Request.Context(), userID.(uuid.UUID), visitorID(c), id)
+	if err != nil {
+		response.Error(c, userErrorStatus(err), "Failed to fetch candidate", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Candidate retrieved", profile)
+}
+
+func queryYears(c *gin.Context, param string) (*float64, error) {
+	v := c.Query(param)
+	if v == "" {
+		return nil, nil
+	}
+	years, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, err
+	}
+	if years < 0 {
+		return nil, errors.New("years must not be negative")
+	}
+	return &years, nil
+}
+
+func userErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrCompanyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidExperienceRange), errors.Is(err, service.ErrInvalidVisibility),
+		errors.Is(err, service.ErrInvalidContactField), errors.Is(err, service.ErrInvalidDateRange):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrEmailTaken), errors.Is(err, service.ErrEmailDeleted):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,25 +1,41 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/schemaorg"
 	"github.com/zahidhasann88/job-board-api/internal/service"
+	"github.com/zahidhasann88/job-board-api/internal/tasks"
+	"github.com/zahidhasann88/job-board-api/pkg/mergepatch"
 	"github.com/zahidhasann88/job-board-api/pkg/response"
 	"github.com/zahidhasann88/job-board-api/pkg/validator"
 )
 
 type JobHandler struct {
 	jobService      *service.JobService
+	templateService *service.JobTemplateService
+	engagement      *service.EngagementService
+	taskRunner      *tasks.Runner
+	postings        *schemaorg.Builder
 	customValidator *validator.CustomValidator
 }
 
-func NewJobHandler(jobService *service.JobService) *JobHandler {
+func NewJobHandler(jobService *service.JobService, templateService *service.JobTemplateService, engagement *service.EngagementService, taskRunner *tasks.Runner, postings *schemaorg.Builder) *JobHandler {
 	return &JobHandler{
 		jobService:      jobService,
+		templateService: templateService,
+		engagement:      engagement,
+		taskRunner:      taskRunner,
+		postings:        postings,
 		customValidator: validator.NewValidator(),
 	}
 }
@@ -56,24 +72,108 @@ func (h *JobHandler) Create(c *gin.Context) {
 		return
 	}
 
-	job := &domain.Job{
-		Title:           req.Title,
-		Description:     req.Description,
-		CompanyID:       userID.(uuid.UUID),
-		Location:        req.Location,
-		SalaryRange:     req.SalaryRange,
-		JobType:         req.JobType,
-		ExperienceLevel: req.ExperienceLevel,
-		Skills:          req.Skills,
-		Status:          "active",
-	}
+	job := jobFromCreateRequest(userID.(uuid.UUID), req)
 
 	if err := h.jobService.CreateJob(c.Request.Context(), job); err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to create job", err.Error())
+		jobError(c, "Failed to create job", err)
+		return
+	}
+
+	switch {
+	case len(job.PossibleDuplicates) > 0:
+		response.Success(c, http.StatusCreated, "Job created; it looks similar to existing postings", job)
+	case job.Status == domain.JobStatusPendingReview:
+		response.Success(c, http.StatusCreated, "Job submitted for review", job)
+	case job.Status == domain.JobStatusDraft:
+		response.Success(c, http.StatusCreated, "Job scheduled for publishing", job)
+	default:
+		response.Success(c, http.StatusCreated, "Job created successfully", job)
+	}
+}
+
+// Clone copies one of the recruiter's jobs into a new draft. The schedule is
+// not copied.
+func (h *JobHandler) Clone(c *gin.Context) {
+	source := h.ownedJob(c)
+	if source == nil {
 		return
 	}
 
-	response.Success(c, http.StatusCreated, "Job created successfully", job)
+	req := domain.CreateJobRequest{
+		Title:               source.Title,
+		Description:         source.Description,
+		Location:            source.Location,
+		SalaryRange:         source.SalaryRange,
+		JobType:             source.JobType,
+		ExperienceLevel:     source.ExperienceLevel,
+		Skills:              source.Skills,
+		Openings:            source.Openings,
+		AutoRejectRemaining: source.AutoRejectRemaining,
+		ApplyMethod:         source.ApplyMethod,
+		ApplyURL:            source.ApplyURL,
+	}
+	h.createDraft(c, req, "Job cloned as draft")
+}
+
+// CreateFromTemplate fills in one of the recruiter's templates and creates
+// the result as a draft.
+func (h *JobHandler) CreateFromTemplate(c *gin.Context) {
+	templateID, err := uuid.Parse(c.Param("tid"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid template ID", err.Error())
+		return
+	}
+
+	var body domain.JobFromTemplateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+			return
+		}
+	}
+
+	userID, _ := c.Get("userID")
+	req, err := h.templateService.Render(c.Request.Context(), templateID, userID.(uuid.UUID), body)
+	if err != nil {
+		jobError(c, "Failed to create job from template", err)
+		return
+	}
+	h.createDraft(c, *req, "Job created from template as draft")
+}
+
+// createDraft validates req exactly as Create does and saves it as a draft.
+func (h *JobHandler) createDraft(c *gin.Context, req domain.CreateJobRequest, message string) {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+	validationCtx := validator.ValidationContext{
+		Role:      validator.UserRole(userRole.(string)),
+		UserID:    userID.(uuid.UUID).String(),
+		CompanyID: userID.(uuid.UUID).String(),
+	}
+	if err := h.customValidator.ValidateWithRole(req, validationCtx); err != nil {
+		response.Error(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	job := jobFromCreateRequest(userID.(uuid.UUID), req)
+	if err := h.jobService.CreateDraftJob(c.Request.Context(), job); err != nil {
+		jobError(c, "Failed to create job", err)
+		return
+	}
+
+	switch {
+	case len(job.PossibleDuplicates) > 0:
+		response.Success(c, http.StatusCreated, message+"; it looks similar to existing postings", job)
+	case job.Status == domain.JobStatusPendingReview:
+		response.Success(c, http.StatusCreated, "Job submitted for review", job)
+	default:
+		response.Success(c, http.StatusCreated, message, job)
+	}
 }
 
 func (h *JobHandler) Update(c *gin.Context) {
@@ -134,25 +234,95 @@ func (h *JobHandler) Update(c *gin.Context) {
 		return
 	}
 
-	// Update job fields
-	job := &domain.Job{
-		ID:              id,
-		Title:           req.Title,
-		Description:     req.Description,
-		Location:        req.Location,
-		SalaryRange:     req.SalaryRange,
-		JobType:         req.JobType,
-		ExperienceLevel: req.ExperienceLevel,
-		Skills:          req.Skills,
-		Status:          req.Status,
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		response.Error(c, http.StatusPreconditionFailed, "Precondition failed", err.Error())
+		return
 	}
 
+	// Update job fields
+	job := jobFromUpdateRequest(id, req)
+	job.Version = version
+
 	// Perform update
-	if err := h.jobService.UpdateJob(c.Request.Context(), job); err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to update job", err.Error())
+	if err := h.jobService.UpdateJob(c.Request.Context(), job, userID.(uuid.UUID)); err != nil {
+		jobError(c, "Failed to update job", err)
 		return
 	}
 
+	setETag(c, job)
+	response.Success(c, http.StatusOK, "Job updated successfully", job)
+}
+
+// Patch applies an RFC 7396 merge patch to a job, so fields left out of the
+// body keep their current values.
+func (h *JobHandler) Patch(c *gin.Context) {
+	existingJob := h.ownedJob(c)
+	if existingJob == nil {
+		return
+	}
+
+	if ct := c.ContentType(); ct != "application/merge-patch+json" && ct != "application/json" {
+		response.Error(c, http.StatusUnsupportedMediaType, "Unsupported media type",
+			"PATCH takes application/merge-patch+json or application/json")
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		response.Error(c, http.StatusPreconditionFailed, "Precondition failed", err.Error())
+		return
+	}
+	if version == 0 {
+		// The patch is merged onto the job as read here, so without If-Match
+		// it must still only apply to that version
+		version = existingJob.Version
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+	current, err := json.Marshal(updateRequestFromJob(existingJob))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to patch job", err.Error())
+		return
+	}
+	merged, err := mergepatch.Apply(current, patch)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	var req domain.UpdateJobRequest
+	if err := json.Unmarshal(merged, &req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+	validationCtx := validator.ValidationContext{
+		Role:      validator.UserRole(userRole.(string)),
+		UserID:    userID.(uuid.UUID).String(),
+		CompanyID: userID.(uuid.UUID).String(),
+	}
+	// The merged document is a whole job again, so it must pass the same
+	// rules as one being created
+	if err := h.customValidator.ValidateWithRole(createRequestFromUpdate(req), validationCtx); err != nil {
+		response.Error(c, http.StatusBadRequest, "Validation failed", err.Error())
+		return
+	}
+
+	job := jobFromUpdateRequest(existingJob.ID, req)
+	job.Version = version
+	if err := h.jobService.UpdateJob(c.Request.Context(), job, userID.(uuid.UUID)); err != nil {
+		jobError(c, "Failed to update job", err)
+		return
+	}
+
+	setETag(c, job)
 	response.Success(c, http.StatusOK, "Job updated successfully", job)
 }
 
@@ -164,7 +334,8 @@ func (h *JobHandler) Get(c *gin.Context) {
 		return
 	}
 
-	job, err := h.jobService.GetJob(c.Request.Context(), id)
+	viewerID, viewerRole := viewer(c)
+	job, err := h.jobService.GetVisibleJob(c.Request.Context(), id, viewerID, viewerRole)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "Failed to fetch job", err.Error())
 		return
@@ -174,22 +345,20 @@ func (h *JobHandler) Get(c *gin.Context) {
 		return
 	}
 
+	if job.Status == domain.JobStatusActive {
+		h.engagement.Record(domain.EngagementView, visitorID(c), job.ID)
+	}
+
+	setETag(c, job)
+	if wantsJSONLD(c) {
+		h.writeJobPosting(c, job)
+		return
+	}
 	response.Success(c, http.StatusOK, "Job retrieved successfully", job)
 }
 
 func (h *JobHandler) List(c *gin.Context) {
-	var filter domain.JobFilter
-	// Get query parameters
-	if loc := c.Query("location"); loc != "" {
-		filter.Location = &loc
-	}
-	if jobType := c.Query("job_type"); jobType != "" {
-		filter.JobType = &jobType
-	}
-	if expLevel := c.Query("experience_level"); expLevel != "" {
-		filter.ExperienceLevel = &expLevel
-	}
-	filter.Skills = c.QueryArray("skills")
+	filter := publicJobFilter(c)
 
 	// Parse pagination
 	if pageStr := c.Query("page"); pageStr != "" {
@@ -212,6 +381,12 @@ func (h *JobHandler) List(c *gin.Context) {
 		return
 	}
 
+	ids := make([]uuid.UUID, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	h.engagement.Record(domain.EngagementImpression, visitorID(c), ids...)
+
 	meta := response.Meta{
 		Total:     total,
 		Page:      filter.Page,
@@ -222,6 +397,26 @@ func (h *JobHandler) List(c *gin.Context) {
 	response.SuccessWithMeta(c, http.StatusOK, "Jobs retrieved successfully", jobs, meta)
 }
 
+// publicJobFilter reads the job list's query parameters. Only published jobs
+// are visible on the public board.
+func publicJobFilter(c *gin.Context) domain.JobFilter {
+	var filter domain.JobFilter
+	if loc := c.Query("location"); loc != "" {
+		filter.Location = &loc
+	}
+	if jobType := c.Query("job_type"); jobType != "" {
+		filter.JobType = &jobType
+	}
+	if expLevel := c.Query("experience_level"); expLevel != "" {
+		filter.ExperienceLevel = &expLevel
+	}
+	filter.Skills = c.QueryArray("skills")
+
+	status := domain.JobStatusActive
+	filter.Status = &status
+	return filter
+}
+
 func (h *JobHandler) ChangeStatus(c *gin.Context) {
 	// Parse job ID from URL
 	idStr := c.Param("id")
@@ -265,15 +460,16 @@ func (h *JobHandler) ChangeStatus(c *gin.Context) {
 	}
 
 	// Change job status
-	if err := h.jobService.ChangeJobStatus(c.Request.Context(), id, req.Status); err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to change job status", err.Error())
+	actorID := userID.(uuid.UUID)
+	if err := h.jobService.ChangeJobStatus(c.Request.Context(), id, req.Status, &actorID); err != nil {
+		jobError(c, "Failed to change job status", err)
 		return
 	}
 
 	response.Success(c, http.StatusOK, "Job status updated successfully", nil)
 }
 
-func (h *JobHandler) CompleteDelete(c *gin.Context) {
+func (h *JobHandler) Delete(c *gin.Context) {
 	// Parse job ID from URL
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -306,13 +502,81 @@ func (h *JobHandler) CompleteDelete(c *gin.Context) {
 		return
 	}
 
-	// Complete delete job and its applications
-	if err := h.jobService.CompleteDeleteJob(c.Request.Context(), id); err != nil {
+	// Move the job to the trash; it is purged once the retention window passes
+	if err := h.jobService.DeleteJob(c.Request.Context(), id); err != nil {
 		response.Error(c, http.StatusInternalServerError, "Failed to delete job", err.Error())
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Job completely deleted successfully", nil)
+	response.Success(c, http.StatusOK, "Job moved to trash", nil)
+}
+
+func (h *JobHandler) Trash(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	jobs, err := h.jobService.ListDeletedJobs(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch deleted jobs", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Deleted jobs retrieved successfully", jobs)
+}
+
+func (h *JobHandler) Restore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid job ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	job, err := h.jobService.RestoreJob(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		jobError(c, "Failed to restore job", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job restored successfully", job)
+}
+
+func (h *JobHandler) Renew(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid job ID", err.Error())
+		return
+	}
+
+	var req domain.RenewJobRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+			return
+		}
+	}
+
+	userID, _ := c.Get("userID")
+	existingJob, err := h.jobService.GetJob(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch job", err.Error())
+		return
+	}
+	if existingJob == nil {
+		response.Error(c, http.StatusNotFound, "Job not found", "")
+		return
+	}
+	if existingJob.CompanyID != userID {
+		response.Error(c, http.StatusForbidden, "Unauthorized", "Not allowed to renew this job")
+		return
+	}
+
+	job, err := h.jobService.RenewJob(c.Request.Context(), id, req.Days, userID.(uuid.UUID))
+	if err != nil {
+		jobError(c, "Failed to renew job", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job renewed successfully", job)
 }
 
 func (h *JobHandler) GetJobAnalytics(c *gin.Context) {
@@ -328,45 +592,182 @@ func (h *JobHandler) GetJobAnalytics(c *gin.Context) {
     response.Success(c, http.StatusOK, "Job analytics retrieved", analytics)
 }
 
-func (h *JobHandler) BulkCreateJobs(c *gin.Context) {
-    var jobs []domain.CreateJobRequest
-    if err := c.ShouldBindJSON(&jobs); err != nil {
-        response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
-        return
-    }
+// GetJobApplicationInsights summarizes the applicants of one of the
+// recruiter's jobs.
+func (h *JobHandler) GetJobApplicationInsights(c *gin.Context) {
+	job := h.ownedJob(c)
+	if job == nil {
+		return
+	}
 
-    userID, _ := c.Get("userID")
-    createdJobs, err := h.jobService.BulkCreateJobs(c.Request.Context(), jobs, userID.(uuid.UUID))
-    if err != nil {
-        response.Error(c, http.StatusInternalServerError, "Failed to create jobs", err.Error())
-        return
-    }
+	insights, err := h.jobService.GetJobApplicationInsights(c.Request.Context(), job.ID)
+	if err != nil {
+		response.Error(c, jobErrorStatus(err), "Failed to fetch application insights", err.Error())
+		return
+	}
 
-    response.Success(c, http.StatusCreated, "Jobs created successfully", createdJobs)
+	response.Success(c, http.StatusOK, "Job application insights retrieved", insights)
 }
 
-func (h *JobHandler) GetJobApplicationInsights(c *gin.Context) {
-    idStr := c.Param("id")
-    jobID, _ := uuid.Parse(idStr)
+// GetRecommendedCandidates ranks job seekers for one of the recruiter's jobs,
+// explaining each candidate's score factor by factor.
+func (h *JobHandler) GetRecommendedCandidates(c *gin.Context) {
+	job := h.ownedJob(c)
+	if job == nil {
+		return
+	}
 
-    insights, err := h.jobService.GetJobApplicationInsights(c.Request.Context(), jobID)
-    if err != nil {
-        response.Error(c, http.StatusInternalServerError, "Failed to fetch application insights", err.Error())
-        return
-    }
+	page, pageSize := pagination(c)
+	candidates, total, err := h.jobService.GetRecommendedCandidates(c.Request.Context(), job.ID, page, pageSize)
+	if err != nil {
+		response.Error(c, jobErrorStatus(err), "Failed to fetch recommended candidates", err.Error())
+		return
+	}
 
-    response.Success(c, http.StatusOK, "Job application insights retrieved", insights)
+	meta := response.Meta{
+		Total:     total,
+		Page:      page,
+		PageSize:  pageSize,
+		TotalPage: (total + pageSize - 1) / pageSize,
+	}
+	response.SuccessWithMeta(c, http.StatusOK, "Recommended candidates retrieved", candidates, meta)
 }
 
-func (h *JobHandler) GetRecommendedCandidates(c *gin.Context) {
-    idStr := c.Param("id")
-    jobID, _ := uuid.Parse(idStr)
+// jobError writes a job service error, including the matching job IDs when a
+// posting was refused as a duplicate.
+func jobError(c *gin.Context, message string, err error) {
+	var dupErr *service.DuplicateJobError
+	if errors.As(err, &dupErr) {
+		response.ErrorWithData(c, http.StatusConflict, message, err.Error(), gin.H{"duplicate_of": dupErr.MatchIDs})
+		return
+	}
+	response.Error(c, jobErrorStatus(err), message, err.Error())
+}
 
-    candidates, err := h.jobService.GetRecommendedCandidates(c.Request.Context(), jobID)
-    if err != nil {
-        response.Error(c, http.StatusInternalServerError, "Failed to fetch recommended candidates", err.Error())
-        return
-    }
+// jobErrorStatus maps job service errors to HTTP status codes.
+func jobErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrJobNotFound), errors.Is(err, service.ErrRevisionNotFound),
+		errors.Is(err, service.ErrTemplateNotFound), errors.Is(err, service.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidJobStatus), errors.Is(err, service.ErrRejectionReasonRequired),
+		errors.Is(err, service.ErrInvalidSchedule), errors.Is(err, service.ErrInvalidTemplate),
+		errors.Is(err, service.ErrMissingPlaceholders), errors.Is(err, service.ErrInvalidApplyMethod):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrInvalidStatusTransition), errors.Is(err, service.ErrNotExternalJob),
+		errors.Is(err, service.ErrDuplicateJob):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrJobBlocked):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrVersionConflict):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, service.ErrRetentionExpired):
+		return http.StatusGone
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func jobFromCreateRequest(companyID uuid.UUID, req domain.CreateJobRequest) *domain.Job {
+	return &domain.Job{
+		Title:               req.Title,
+		Description:         req.Description,
+		CompanyID:           companyID,
+		Location:            req.Location,
+		SalaryRange:         req.SalaryRange,
+		JobType:             req.JobType,
+		ExperienceLevel:     req.ExperienceLevel,
+		Skills:              req.Skills,
+		Openings:            req.Openings,
+		AutoRejectRemaining: req.AutoRejectRemaining,
+		ApplyMethod:         req.ApplyMethod,
+		ApplyURL:            req.ApplyURL,
+		PublishAt:           req.PublishAt,
+		ExpiresAt:           req.ExpiresAt,
+		ApplicationDeadline: req.ApplicationDeadline,
+	}
+}
+
+func jobFromUpdateRequest(id uuid.UUID, req domain.UpdateJobRequest) *domain.Job {
+	return &domain.Job{
+		ID:                  id,
+		Title:               req.Title,
+		Description:         req.Description,
+		Location:            req.Location,
+		SalaryRange:         req.SalaryRange,
+		JobType:             req.JobType,
+		ExperienceLevel:     req.ExperienceLevel,
+		Skills:              req.Skills,
+		Status:              req.Status,
+		Openings:            req.Openings,
+		AutoRejectRemaining: req.AutoRejectRemaining,
+		ApplyMethod:         req.ApplyMethod,
+		ApplyURL:            req.ApplyURL,
+		PublishAt:           req.PublishAt,
+		ExpiresAt:           req.ExpiresAt,
+		ApplicationDeadline: req.ApplicationDeadline,
+	}
+}
 
-    response.Success(c, http.StatusOK, "Recommended candidates retrieved", candidates)
-}
\ No newline at end of file
+func updateRequestFromJob(job *domain.Job) domain.UpdateJobRequest {
+	return domain.UpdateJobRequest{
+		Title:               job.Title,
+		Description:         job.Description,
+		Location:            job.Location,
+		SalaryRange:         job.SalaryRange,
+		JobType:             job.JobType,
+		ExperienceLevel:     job.ExperienceLevel,
+		Skills:              job.Skills,
+		Status:              job.Status,
+		Openings:            job.Openings,
+		AutoRejectRemaining: job.AutoRejectRemaining,
+		ApplyMethod:         job.ApplyMethod,
+		ApplyURL:            job.ApplyURL,
+		PublishAt:           job.PublishAt,
+		ExpiresAt:           job.ExpiresAt,
+		ApplicationDeadline: job.ApplicationDeadline,
+	}
+}
+
+func createRequestFromUpdate(req domain.UpdateJobRequest) domain.CreateJobRequest {
+	return domain.CreateJobRequest{
+		Title:               req.Title,
+		Description:         req.Description,
+		Location:            req.Location,
+		SalaryRange:         req.SalaryRange,
+		JobType:             req.JobType,
+		ExperienceLevel:     req.ExperienceLevel,
+		Skills:              req.Skills,
+		Openings:            req.Openings,
+		AutoRejectRemaining: req.AutoRejectRemaining,
+		ApplyMethod:         req.ApplyMethod,
+		ApplyURL:            req.ApplyURL,
+		PublishAt:           req.PublishAt,
+		ExpiresAt:           req.ExpiresAt,
+		ApplicationDeadline: req.ApplicationDeadline,
+	}
+}
+
+func setETag(c *gin.Context, job *domain.Job) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, job.Version))
+}
+
+// ifMatchVersion returns the job version named by the If-Match header, or 0
+// when the header is absent or "*" and any version may be overwritten.
+// If-Match uses strong comparison, so weak tags never match.
+func ifMatchVersion(c *gin.Context) (int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if strings.HasPrefix(header, "W/") {
+		return 0, fmt.Errorf("If-Match %s is a weak tag and cannot match", header)
+	}
+
+	tag := strings.Trim(header, `"`)
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("If-Match %s does not name a job version", header)
+	}
+	return version, nil
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+// Apply sends candidates of external jobs through the click tracking
+// endpoint to the company's own site. Internal jobs take applications with
+// POST /api/v1/applications.
+func (h *JobHandler) Apply(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid job ID", err.Error())
+		return
+	}
+
+	job, err := h.jobService.GetJob(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch job", err.Error())
+		return
+	}
+	if job == nil || job.Status != domain.JobStatusActive {
+		response.Error(c, http.StatusNotFound, "Job not found", "")
+		return
+	}
+	h.engagement.Record(domain.EngagementApplyStart, visitorID(c), job.ID)
+
+	if job.ApplyMethod != domain.ApplyMethodExternal {
+		response.Success(c, http.StatusOK, "Apply with POST /api/v1/applications", gin.H{
+			"job_id":       job.ID,
+			"apply_method": job.ApplyMethod,
+		})
+		return
+	}
+
+	// Browsers drop the Referer on some redirects, so it is passed along
+	query := url.Values{}
+	if ref := referrer(c); ref != "" {
+		query.Set("ref", ref)
+	}
+	target := "/api/v1/jobs/" + job.ID.String() + "/apply/track"
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	c.Redirect(http.StatusFound, target)
+}
+
+// TrackApply records a click through to an external job's apply URL and
+// redirects there. Only a hash of the visitor is stored. Leaving for the
+// company's site is the last step we see, so it completes the apply funnel.
+func (h *JobHandler) TrackApply(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid job ID", err.Error())
+		return
+	}
+
+	visitor := visitorID(c)
+	applyURL, err := h.jobService.TrackApplyClick(c.Request.Context(), id, referrer(c), visitor)
+	if err != nil {
+		response.Error(c, jobErrorStatus(err), "Failed to open apply page", err.Error())
+		return
+	}
+	h.engagement.Record(domain.EngagementApplyComplete, visitor, id)
+
+	c.Redirect(http.StatusFound, applyURL)
+}
+
+// referrer returns where the candidate came from: the ref query parameter
+// when given, otherwise the Referer header.
+func referrer(c *gin.Context) string {
+	if ref := c.Query("ref"); ref != "" {
+		return ref
+	}
+	return c.Request.Referer()
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/service"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+func (h *JobHandler) BulkCreateJobs(c *gin.Context) {
+	var jobs []domain.CreateJobRequest
+	if err := c.ShouldBindJSON(&jobs); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+	if len(jobs) == 0 {
+		response.Error(c, http.StatusBadRequest, "Invalid request", "at least one job is required")
+		return
+	}
+
+	h.submitTask(c, domain.TaskBulkCreateJobs, len(jobs), jobs)
+}
+
+func (h *JobHandler) BulkChangeStatus(c *gin.Context) {
+	var req domain.BulkJobStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+	if err := service.ValidateJobStatus(req.Status); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+	if msg := checkJobSelection(req.JobIDs, req.Filter); msg != "" {
+		response.Error(c, http.StatusBadRequest, "Invalid request", msg)
+		return
+	}
+
+	h.submitTask(c, domain.TaskBulkJobStatus, len(req.JobIDs), req)
+}
+
+func (h *JobHandler) BulkClose(c *gin.Context) {
+	var req domain.BulkCloseJobsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	if msg := checkJobSelection(req.JobIDs, req.Filter); msg != "" {
+		response.Error(c, http.StatusBadRequest, "Invalid request", msg)
+		return
+	}
+
+	h.submitTask(c, domain.TaskBulkCloseJobs, len(req.JobIDs), req)
+}
+
+// checkJobSelection returns why a bulk request's job selection is invalid,
+// or an empty string when it is valid.
+func checkJobSelection(ids []uuid.UUID, filter *domain.JobFilter) string {
+	switch {
+	case len(ids) > 0 && filter != nil:
+		return "job_ids and filter cannot be combined"
+	case len(ids) == 0 && filter == nil:
+		return "job_ids or filter is required"
+	}
+	return ""
+}
+
+// submitTask queues a background task for the current user and points the
+// client at the task's status endpoint.
+func (h *JobHandler) submitTask(c *gin.Context, taskType string, total int, payload interface{}) {
+	userID, _ := c.Get("userID")
+	task, err := h.taskRunner.Submit(c.Request.Context(), userID.(uuid.UUID), taskType, total, payload)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to queue task", err.Error())
+		return
+	}
+
+	c.Header("Location", "/api/v1/tasks/"+task.ID.String())
+	response.Success(c, http.StatusAccepted, "Task queued", task)
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/feeds"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+// feedFlushEvery is how many jobs are written between flushes of a feed to
+// the client.
+const feedFlushEvery = 100
+
+func (h *JobHandler) RSSFeed(c *gin.Context) {
+	h.feed(c, feeds.FormatRSS)
+}
+
+func (h *JobHandler) AtomFeed(c *gin.Context) {
+	h.feed(c, feeds.FormatAtom)
+}
+
+func (h *JobHandler) IndeedFeed(c *gin.Context) {
+	h.feed(c, feeds.FormatIndeed)
+}
+
+// feed streams the jobs matching the job list's filters, optionally limited
+// to one company with company_id. Responses carry an ETag and Last-Modified
+// derived from the newest job update, so unchanged feeds answer 304.
+func (h *JobHandler) feed(c *gin.Context, format string) {
+	ctx := c.Request.Context()
+	filter := publicJobFilter(c)
+
+	channel := feeds.Channel{
+		Title:       h.postings.SiteName + " jobs",
+		Description: "Open positions on " + h.postings.SiteName,
+		Link:        h.postings.SiteURL,
+		Self:        requestURL(c),
+	}
+
+	if idStr := c.Query("company_id"); idStr != "" {
+		companyID, err := uuid.Parse(idStr)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid company ID", err.Error())
+			return
+		}
+		company, err := h.jobService.GetCompany(ctx, companyID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to fetch company", err.Error())
+			return
+		}
+		if company == nil {
+			response.Error(c, http.StatusNotFound, "Company not found", "")
+			return
+		}
+
+		name := company.DisplayName()
+		filter.CompanyID = &companyID
+		channel.Title = name + " jobs on " + h.postings.SiteName
+		channel.Description = "Open positions at " + name
+	}
+
+	updated, count, err := h.jobService.JobFeedState(ctx, filter)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to build feed", err.Error())
+		return
+	}
+	channel.Updated = updated
+
+	etag := feedETag(format, c.Request.URL.RawQuery, updated, count)
+	c.Header("ETag", etag)
+	if !updated.IsZero() {
+		c.Header("Last-Modified", updated.UTC().Format(http.TimeFormat))
+	}
+	if notModified(c, etag, updated) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Header("Content-Type", feeds.ContentType(format))
+	c.Status(http.StatusOK)
+
+	w, err := feeds.NewWriter(format, c.Writer, h.postings.JobURL)
+	if err == nil {
+		err = w.Begin(channel)
+	}
+	written := 0
+	if err == nil {
+		err = h.jobService.StreamJobs(ctx, filter, func(job *domain.Job, company *domain.User) error {
+			if err := w.Job(job, company); err != nil {
+				return err
+			}
+			if written++; written%feedFlushEvery == 0 {
+				c.Writer.Flush()
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		err = w.End()
+	}
+	if err != nil {
+		// The status line has been sent; all that is left is to log it
+		c.Error(err)
+	}
+}
+
+// feedETag identifies a version of a feed: its format and query, and the
+// newest update and number of the jobs in it. The count changes when a job
+// leaves the feed without any remaining job being updated.
+func feedETag(format, query string, updated time.Time, count int) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%d|%d", format, query, updated.UnixNano(), count)))
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// notModified checks the request's conditional headers against a feed's
+// ETag and last update. If-None-Match takes precedence when present.
+func notModified(c *gin.Context, etag string, updated time.Time) bool {
+	if inm := c.GetHeader("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := c.GetHeader("If-Modified-Since"); ims != "" && !updated.IsZero() {
+		since, err := http.ParseTime(ims)
+		return err == nil && !updated.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+// requestURL rebuilds the absolute URL of the current request.
+func requestURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host + c.Request.URL.RequestURI()
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/service"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+// defaultReportDays is the range of funnel and dashboard reports when no
+// from date is given.
+const defaultReportDays = 30
+
+// Funnel reports impressions, views, apply starts and completed applications
+// per day for one of the recruiter's jobs. from and to are YYYY-MM-DD and
+// default to the last 30 days.
+func (h *JobHandler) Funnel(c *gin.Context) {
+	job := h.ownedJob(c)
+	if job == nil {
+		return
+	}
+
+	from, to, err := dateRange(c, defaultReportDays)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid date range", err.Error())
+		return
+	}
+
+	funnel, err := h.engagement.JobFunnel(c.Request.Context(), job.ID, from, to)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidDateRange) {
+			status = http.StatusBadRequest
+		}
+		response.Error(c, status, "Failed to fetch job funnel", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job funnel retrieved successfully", funnel)
+}
+
+// visitorID identifies who made a request for engagement tracking: signed-in
+// users by their ID and anonymous visitors by IP address and user agent.
+func visitorID(c *gin.Context) string {
+	if userID, ok := c.Get("userID"); ok {
+		return "user:" + userID.(uuid.UUID).String()
+	}
+	return "anon:" + c.ClientIP() + "|" + c.Request.UserAgent()
+}
+
+// viewer returns the signed-in user and their role, or nil and an empty role
+// on routes where signing in is optional.
+func viewer(c *gin.Context) (*uuid.UUID, domain.UserRole) {
+	userID, ok := c.Get("userID")
+	if !ok {
+		return nil, ""
+	}
+	id := userID.(uuid.UUID)
+	role, _ := c.Get("userRole")
+	return &id, domain.UserRole(role.(string))
+}
+
+// dateRange reads the from and to query parameters (YYYY-MM-DD). to defaults
+// to today and from to the given number of days up to and including to.
+func dateRange(c *gin.Context, defaultDays int) (from, to time.Time, err error) {
+	to = time.Now().UTC()
+	if s := c.Query("to"); s != "" {
+		if to, err = time.Parse(time.DateOnly, s); err != nil {
+			return from, to, fmt.Errorf("invalid to date: %w", err)
+		}
+	}
+	from = to.AddDate(0, 0, 1-defaultDays)
+	if s := c.Query("from"); s != "" {
+		if from, err = time.Parse(time.DateOnly, s); err != nil {
+			return from, to, fmt.Errorf("invalid from date: %w", err)
+		}
+	}
+	return from, to, nil
+}
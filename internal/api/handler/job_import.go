@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/jobimport"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+	"github.com/zahidhasann88/job-board-api/pkg/validator"
+)
+
+// Import creates jobs from an uploaded CSV or XLSX file. The multipart form
+// takes the file, an optional JSON "mapping" of job fields to column
+// headers, "dry_run" and "mode" (all_or_nothing or best_effort).
+func (h *JobHandler) Import(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", "a file is required")
+		return
+	}
+
+	opts := domain.JobImportOptions{Mode: c.DefaultPostForm("mode", domain.ImportModeAllOrNothing)}
+	if opts.Mode != domain.ImportModeAllOrNothing && opts.Mode != domain.ImportModeBestEffort {
+		response.Error(c, http.StatusBadRequest, "Invalid request", "mode must be all_or_nothing or best_effort")
+		return
+	}
+	if value := c.PostForm("dry_run"); value != "" {
+		if opts.DryRun, err = strconv.ParseBool(value); err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid request", "dry_run must be true or false")
+			return
+		}
+	}
+	if value := c.PostForm("mapping"); value != "" {
+		if err := json.Unmarshal([]byte(value), &opts.Mapping); err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid request", "mapping must be a JSON object of field names to column headers")
+			return
+		}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+	defer file.Close()
+
+	table, err := jobimport.ReadTable(file, header.Filename)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to read file", err.Error())
+		return
+	}
+	rows, err := jobimport.Parse(table, opts.Mapping)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Failed to read file", err.Error())
+		return
+	}
+
+	// Every row goes through the same validation as a job created through
+	// the API
+	userID, _ := c.Get("userID")
+	userRole, _ := c.Get("userRole")
+	validationCtx := validator.ValidationContext{
+		Role:      validator.UserRole(userRole.(string)),
+		UserID:    userID.(uuid.UUID).String(),
+		CompanyID: userID.(uuid.UUID).String(),
+	}
+	for i := range rows {
+		if rows[i].Blank {
+			continue
+		}
+		err := h.customValidator.ValidateWithRole(rows[i].Request, validationCtx)
+		var fieldErrs validator.ValidationErrors
+		if errors.As(err, &fieldErrs) {
+			for _, fieldErr := range fieldErrs {
+				rows[i].Errors = append(rows[i].Errors, domain.ImportFieldError{
+					Field:   fieldErr.Field,
+					Message: fieldErr.Message,
+				})
+			}
+		}
+	}
+
+	report, err := h.jobService.ImportJobs(c.Request.Context(), userID.(uuid.UUID), rows, opts)
+	if err != nil {
+		jobError(c, "Failed to import jobs", err)
+		return
+	}
+
+	switch {
+	case opts.DryRun:
+		response.Success(c, http.StatusOK, "Dry run completed; no jobs were created", report)
+	case report.Failed > 0 && opts.Mode == domain.ImportModeAllOrNothing:
+		response.ErrorWithData(c, http.StatusUnprocessableEntity, "Import failed; no jobs were created",
+			"some rows are invalid", report)
+	case report.Created > 0:
+		response.Success(c, http.StatusCreated, "Jobs imported", report)
+	default:
+		response.Success(c, http.StatusOK, "No jobs were imported", report)
+	}
+}
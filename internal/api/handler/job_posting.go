@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/schemaorg"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+const jsonLDContentType = "application/ld+json"
+
+// wantsJSONLD reports whether the client asked for schema.org structured
+// data, with ?format=jsonld or an Accept header.
+func wantsJSONLD(c *gin.Context) bool {
+	return c.Query("format") == "jsonld" || strings.Contains(c.GetHeader("Accept"), jsonLDContentType)
+}
+
+func (h *JobHandler) writeJobPosting(c *gin.Context, job *domain.Job) {
+	company, err := h.jobService.GetCompany(c.Request.Context(), job.CompanyID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch job", err.Error())
+		return
+	}
+
+	// gin keeps a Content-Type that is already set
+	c.Header("Content-Type", jsonLDContentType+"; charset=utf-8")
+	c.JSON(http.StatusOK, h.postings.JobPosting(job, company))
+}
+
+// Sitemap lists the public pages of all active jobs. Beyond the sitemap
+// protocol's limit of URLs per file it serves a sitemap index instead, whose
+// entries point back here with a page parameter.
+func (h *JobHandler) Sitemap(c *gin.Context) {
+	page := 1
+	if p := c.Query("page"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			response.Error(c, http.StatusBadRequest, "Invalid page", "page must be a positive number")
+			return
+		}
+		page = n
+	}
+
+	jobs, total, err := h.jobService.ListActiveJobs(c.Request.Context(), page, schemaorg.SitemapLimit)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to build sitemap", err.Error())
+		return
+	}
+
+	c.Header("Content-Type", "application/xml; charset=utf-8")
+	c.Status(http.StatusOK)
+	if c.Query("page") == "" && total > schemaorg.SitemapLimit {
+		pages := (total + schemaorg.SitemapLimit - 1) / schemaorg.SitemapLimit
+		err = h.postings.WriteSitemapIndex(c.Writer, pages)
+	} else {
+		err = h.postings.WriteSitemap(c.Writer, jobs)
+	}
+	if err != nil {
+		c.Error(err)
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+// Number of similar jobs shown by default and at most
+const (
+	defaultSimilarJobs = 5
+	maxSimilarJobs     = 20
+)
+
+// RecommendedJobs ranks active jobs for the signed-in job seeker, explaining
+// each job's score factor by factor.
+func (h *JobHandler) RecommendedJobs(c *gin.Context) {
+	page, pageSize := pagination(c)
+	userID, _ := c.Get("userID")
+
+	jobs, total, err := h.jobService.RecommendJobs(c.Request.Context(), userID.(uuid.UUID), page, pageSize)
+	if err != nil {
+		response.Error(c, jobErrorStatus(err), "Failed to fetch recommended jobs", err.Error())
+		return
+	}
+
+	meta := response.Meta{
+		Total:     total,
+		Page:      page,
+		PageSize:  pageSize,
+		TotalPage: (total + pageSize - 1) / pageSize,
+	}
+	response.SuccessWithMeta(c, http.StatusOK, "Recommended jobs retrieved", jobs, meta)
+}
+
+// NotInterested hides a job from the seeker's recommendations and ranks
+// similar jobs lower.
+func (h *JobHandler) NotInterested(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid job ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	if err := h.jobService.MarkNotInterested(c.Request.Context(), userID.(uuid.UUID), id); err != nil {
+		response.Error(c, jobErrorStatus(err), "Failed to mark job as not interested", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job marked as not interested", nil)
+}
+
+// ClearNotInterested undoes NotInterested.
+func (h *JobHandler) ClearNotInterested(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid job ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	if err := h.jobService.ClearNotInterested(c.Request.Context(), userID.(uuid.UUID), id); err != nil {
+		response.Error(c, jobErrorStatus(err), "Failed to clear not interested", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job no longer marked as not interested", nil)
+}
+
+// pagination reads the page and page_size query parameters of ranked lists.
+// Similar lists active jobs like the given one, for showing related openings
+// next to a job.
+func (h *JobHandler) Similar(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid job ID", err.Error())
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	if limit < 1 {
+		limit = defaultSimilarJobs
+	}
+	limit = min(limit, maxSimilarJobs)
+
+	viewerID, viewerRole := viewer(c)
+	similar, err := h.jobService.SimilarJobs(c.Request.Context(), id, viewerID, viewerRole, limit)
+	if err != nil {
+		response.Error(c, jobErrorStatus(err), "Failed to fetch similar jobs", err.Error())
+		return
+	}
+
+	ids := make([]uuid.UUID, len(similar))
+	for i, s := range similar {
+		ids[i] = s.Job.ID
+	}
+	h.engagement.Record(domain.EngagementImpression, visitorID(c), ids...)
+
+	response.Success(c, http.StatusOK, "Similar jobs retrieved", similar)
+}
+
+func pagination(c *gin.Context) (page, pageSize int) {
+	page, _ = strconv.Atoi(c.Query("page"))
+	pageSize, _ = strconv.Atoi(c.Query("page_size"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	return page, pageSize
+}
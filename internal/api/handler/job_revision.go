@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+// ownedJob loads the job named in the URL and checks that it belongs to the
+// current recruiter. It writes the error response itself and returns nil when
+// the request should stop.
+func (h *JobHandler) ownedJob(c *gin.Context) *domain.Job {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid job ID", err.Error())
+		return nil
+	}
+
+	job, err := h.jobService.GetJob(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch job", err.Error())
+		return nil
+	}
+	if job == nil {
+		response.Error(c, http.StatusNotFound, "Job not found", "")
+		return nil
+	}
+
+	userID, _ := c.Get("userID")
+	if job.CompanyID != userID {
+		response.Error(c, http.StatusForbidden, "Unauthorized", "Not allowed to access this job")
+		return nil
+	}
+	return job
+}
+
+func (h *JobHandler) ListRevisions(c *gin.Context) {
+	job := h.ownedJob(c)
+	if job == nil {
+		return
+	}
+
+	revisions, err := h.jobService.ListRevisions(c.Request.Context(), job.ID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch job revisions", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job revisions retrieved successfully", revisions)
+}
+
+func (h *JobHandler) DiffRevisions(c *gin.Context) {
+	job := h.ownedJob(c)
+	if job == nil {
+		return
+	}
+
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid from revision", err.Error())
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid to revision", err.Error())
+		return
+	}
+
+	diff, err := h.jobService.DiffRevisions(c.Request.Context(), job.ID, from, to)
+	if err != nil {
+		jobError(c, "Failed to diff job revisions", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job revision diff retrieved successfully", diff)
+}
+
+func (h *JobHandler) RestoreRevision(c *gin.Context) {
+	job := h.ownedJob(c)
+	if job == nil {
+		return
+	}
+
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid revision", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	restored, err := h.jobService.RestoreRevision(c.Request.Context(), job.ID, revision, userID.(uuid.UUID))
+	if err != nil {
+		jobError(c, "Failed to restore job revision", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job revision restored successfully", restored)
+}
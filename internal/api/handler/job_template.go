@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/service"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+type JobTemplateHandler struct {
+	templateService *service.JobTemplateService
+}
+
+func NewJobTemplateHandler(templateService *service.JobTemplateService) *JobTemplateHandler {
+	return &JobTemplateHandler{templateService: templateService}
+}
+
+func (h *JobTemplateHandler) Create(c *gin.Context) {
+	var req domain.CreateJobTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	template, err := h.templateService.CreateTemplate(c.Request.Context(), userID.(uuid.UUID), req)
+	if err != nil {
+		jobError(c, "Failed to create job template", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Job template created successfully", template)
+}
+
+func (h *JobTemplateHandler) List(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	templates, err := h.templateService.ListTemplates(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch job templates", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job templates retrieved successfully", templates)
+}
+
+func (h *JobTemplateHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("tid"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid template ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	template, err := h.templateService.GetTemplate(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		jobError(c, "Failed to fetch job template", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job template retrieved successfully", template)
+}
+
+func (h *JobTemplateHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("tid"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid template ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	if err := h.templateService.DeleteTemplate(c.Request.Context(), id, userID.(uuid.UUID)); err != nil {
+		jobError(c, "Failed to delete job template", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job template deleted successfully", nil)
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/service"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+type ModerationHandler struct {
+	jobService  *service.JobService
+	userService *service.UserService
+}
+
+func NewModerationHandler(jobService *service.JobService, userService *service.UserService) *ModerationHandler {
+	return &ModerationHandler{
+		jobService:  jobService,
+		userService: userService,
+	}
+}
+
+func (h *ModerationHandler) Queue(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	jobs, total, err := h.jobService.ListModerationQueue(c.Request.Context(), page, pageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch moderation queue", err.Error())
+		return
+	}
+
+	meta := response.Meta{
+		Total:     total,
+		Page:      page,
+		PageSize:  pageSize,
+		TotalPage: (total + pageSize - 1) / pageSize,
+	}
+
+	response.SuccessWithMeta(c, http.StatusOK, "Moderation queue retrieved", jobs, meta)
+}
+
+func (h *ModerationHandler) Approve(c *gin.Context) {
+	h.review(c, true)
+}
+
+func (h *ModerationHandler) Reject(c *gin.Context) {
+	h.review(c, false)
+}
+
+func (h *ModerationHandler) review(c *gin.Context, approve bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid job ID", err.Error())
+		return
+	}
+
+	var req domain.ReviewJobRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+			return
+		}
+	}
+
+	reviewerID, _ := c.Get("userID")
+	job, err := h.jobService.ReviewJob(c.Request.Context(), id, reviewerID.(uuid.UUID), approve, req.Reason, req.Fraud)
+	if err != nil {
+		response.Error(c, jobErrorStatus(err), "Failed to review job", err.Error())
+		return
+	}
+
+	if approve {
+		response.Success(c, http.StatusOK, "Job approved", job)
+		return
+	}
+	response.Success(c, http.StatusOK, "Job rejected", job)
+}
+
+func (h *ModerationHandler) DuplicateReport(c *gin.Context) {
+	threshold, _ := strconv.ParseFloat(c.Query("threshold"), 64)
+	if threshold < 0 || threshold > 1 {
+		response.Error(c, http.StatusBadRequest, "Invalid threshold", "threshold must be between 0 and 1")
+		return
+	}
+
+	clusters, err := h.jobService.DuplicateReport(c.Request.Context(), threshold)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to build duplicate report", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Duplicate report generated", clusters)
+}
+
+func (h *ModerationHandler) VerifyCompany(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid user ID", err.Error())
+		return
+	}
+
+	if err := h.userService.SetVerified(c.Request.Context(), id, true); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to verify company", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Company verified", nil)
+}
+
+func (h *ModerationHandler) RestoreUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid user ID", err.Error())
+		return
+	}
+
+	user, err := h.userService.RestoreUser(c.Request.Context(), id)
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		response.Error(c, http.StatusNotFound, "User not found", err.Error())
+		return
+	case errors.Is(err, service.ErrRetentionExpired):
+		response.Error(c, http.StatusGone, "Failed to restore user", err.Error())
+		return
+	case err != nil:
+		response.Error(c, http.StatusInternalServerError, "Failed to restore user", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User restored successfully", user)
+}
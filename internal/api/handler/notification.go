@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/service"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+type NotificationHandler struct {
+	notificationService *service.NotificationService
+}
+
+func NewNotificationHandler(notificationService *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+func (h *NotificationHandler) List(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	filter := domain.NotificationFilter{
+		UserID:     userID.(uuid.UUID),
+		UnreadOnly: c.Query("unread") == "true",
+	}
+	filter.Page, _ = strconv.Atoi(c.Query("page"))
+	filter.PageSize, _ = strconv.Atoi(c.Query("page_size"))
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 20
+	}
+
+	notifications, total, err := h.notificationService.List(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to list notifications", err.Error())
+		return
+	}
+
+	meta := response.Meta{
+		Total:     total,
+		Page:      filter.Page,
+		PageSize:  filter.PageSize,
+		TotalPage: (total + filter.PageSize - 1) / filter.PageSize,
+	}
+
+	response.SuccessWithMeta(c, http.StatusOK, "Notifications retrieved successfully", notifications, meta)
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid notification ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	if err := h.notificationService.MarkRead(c.Request.Context(), id, userID.(uuid.UUID)); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to update notification", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Notification marked as read", nil)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+func (h *UserHandler) GetPrivacy(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	settings, err := h.userService.GetPrivacySettings(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		response.Error(c, userErrorStatus(err), "Failed to fetch privacy settings", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Privacy settings retrieved", settings)
+}
+
+func (h *UserHandler) UpdatePrivacy(c *gin.Context) {
+	var req domain.UpdatePrivacySettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	settings, err := h.userService.UpdatePrivacySettings(c.Request.Context(), userID.(uuid.UUID), req)
+	if err != nil {
+		response.Error(c, userErrorStatus(err), "Failed to update privacy settings", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Privacy settings updated", settings)
+}
+
+func (h *UserHandler) BlockCompany(c *gin.Context) {
+	var req domain.BlockCompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid request", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	settings, err := h.userService.BlockCompany(c.Request.Context(), userID.(uuid.UUID), req.CompanyID)
+	if err != nil {
+		response.Error(c, userErrorStatus(err), "Failed to block company", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Company blocked", settings)
+}
+
+func (h *UserHandler) UnblockCompany(c *gin.Context) {
+	companyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid company ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	settings, err := h.userService.UnblockCompany(c.Request.Context(), userID.(uuid.UUID), companyID)
+	if err != nil {
+		response.Error(c, userErrorStatus(err), "Failed to unblock company", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Company unblocked", settings)
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+func (h *UserHandler) GetMe(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	profile, err := h.userService.GetProfile(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		response.Error(c, userErrorStatus(err), "Failed to fetch profile", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Profile retrieved", profile)
+}
+
+// GetProfile shows a user's profile to anyone, signed in or not, as far as
+// the user's privacy settings allow, and counts it as a profile view.
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid user ID", err.Error())
+		return
+	}
+
+	viewerID, viewerRole := viewer(c)
+	profile, err := h.userService.ViewProfile(c.Request.Context(), viewerID, viewerRole, visitorID(c), id)
+	if err != nil {
+		response.Error(c, userErrorStatus(err), "Failed to fetch profile", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Profile retrieved", profile)
+}
+
+// GetAnalytics reports the views of the current user's profile. from and to
+// are YYYY-MM-DD and default to the last 30 days.
+func (h *UserHandler) GetAnalytics(c *gin.Context) {
+	from, to, err := dateRange(c, defaultReportDays)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid date range", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	analytics, err := h.userService.GetUserAnalytics(c.Request.Context(), userID.(uuid.UUID), from, to)
+	if err != nil {
+		response.Error(c, userErrorStatus(err), "Failed to fetch profile analytics", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Profile analytics retrieved", analytics)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zahidhasann88/job-board-api/internal/skills"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+// Number of skill suggestions returned by default and at most
+const (
+	defaultSkillSuggestions = 10
+	maxSkillSuggestions     = 50
+)
+
+type SkillHandler struct {
+	taxonomy *skills.Taxonomy
+}
+
+func NewSkillHandler(taxonomy *skills.Taxonomy) *SkillHandler {
+	return &SkillHandler{taxonomy: taxonomy}
+}
+
+// Suggest autocompletes skill names from the taxonomy, matching the start of
+// a skill's name or any of its aliases.
+func (h *SkillHandler) Suggest(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	if limit < 1 {
+		limit = defaultSkillSuggestions
+	}
+	limit = min(limit, maxSkillSuggestions)
+
+	response.Success(c, http.StatusOK, "Skills retrieved", h.taxonomy.Suggest(c.Query("q"), limit))
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/tasks"
+	"github.com/zahidhasann88/job-board-api/pkg/response"
+)
+
+type TaskHandler struct {
+	taskRunner *tasks.Runner
+}
+
+func NewTaskHandler(taskRunner *tasks.Runner) *TaskHandler {
+	return &TaskHandler{taskRunner: taskRunner}
+}
+
+func (h *TaskHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	task, err := h.taskRunner.Get(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		response.Error(c, taskErrorStatus(err), "Failed to fetch task", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Task retrieved", task)
+}
+
+func (h *TaskHandler) Cancel(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid task ID", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	task, err := h.taskRunner.Cancel(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		response.Error(c, taskErrorStatus(err), "Failed to cancel task", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Task cancellation requested", task)
+}
+
+func taskErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tasks.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, tasks.ErrTaskFinished):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
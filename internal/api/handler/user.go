@@ -32,7 +32,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 	}
 
 	if err := h.userService.CreateUser(c.Request.Context(), user, req.Password); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -97,7 +97,7 @@ func (h *UserHandler) UpdateProfileDetails(c *gin.Context) {
 	}
 
 	if err := h.userService.UpdateProfileDetails(c.Request.Context(), userID.(uuid.UUID), updates); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -120,3 +120,16 @@ func (h *UserHandler) UpdateEmploymentHistory(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Employment history updated successfully"})
 }
+
+// DeleteAccount moves the current user to the trash. An admin can restore the
+// account until the retention window passes.
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	if err := h.userService.DeleteUser(c.Request.Context(), userID.(uuid.UUID)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account deleted successfully"})
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -26,36 +27,57 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		token := parts[1]
-		claims := jwt.MapClaims{}
-
-		// Parse and validate token
-		parsedToken, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !parsedToken.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-			c.Abort()
-			return
-		}
-
-		// Extract user ID and role from claims
-		userID, err := uuid.Parse(claims["user_id"].(string))
+		userID, role, err := parseToken(parts[1], jwtSecret)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token claims"})
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			c.Abort()
 			return
 		}
 
 		// Set user info in context
 		c.Set("userID", userID)
-		c.Set("userRole", claims["role"].(string))
+		c.Set("userRole", role)
 
 		c.Next()
 	}
 }
 
+// OptionalAuth sets the user info like AuthMiddleware when the request
+// carries a valid token, and lets the request through anonymously otherwise.
+func OptionalAuth(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if userID, role, err := parseToken(parts[1], jwtSecret); err == nil {
+				c.Set("userID", userID)
+				c.Set("userRole", role)
+			}
+		}
+		c.Next()
+	}
+}
+
+func parseToken(token, jwtSecret string) (uuid.UUID, string, error) {
+	claims := jwt.MapClaims{}
+
+	// Parse and validate token
+	parsedToken, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(jwtSecret), nil
+	})
+	if err != nil || !parsedToken.Valid {
+		return uuid.Nil, "", errors.New("invalid token")
+	}
+
+	// Extract user ID and role from claims
+	idClaim, _ := claims["user_id"].(string)
+	role, _ := claims["role"].(string)
+	userID, err := uuid.Parse(idClaim)
+	if err != nil {
+		return uuid.Nil, "", errors.New("invalid token claims")
+	}
+	return userID, role, nil
+}
+
 func RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("userRole")
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,17 +13,28 @@ import (
 	"github.com/zahidhasann88/job-board-api/internal/api/handler"
 	"github.com/zahidhasann88/job-board-api/internal/api/middleware"
 	"github.com/zahidhasann88/job-board-api/internal/config"
+	"github.com/zahidhasann88/job-board-api/internal/schemaorg"
 	"github.com/zahidhasann88/job-board-api/internal/service"
+	"github.com/zahidhasann88/job-board-api/internal/skills"
+	"github.com/zahidhasann88/job-board-api/internal/tasks"
 	"go.uber.org/zap"
 )
 
+// shutdownTimeout is how long requests in flight get to finish on shutdown.
+const shutdownTimeout = 10 * time.Second
+
 type Server struct {
-	config             *config.Config
-	logger             *zap.Logger
-	router             *gin.Engine
-	userHandler        *handler.UserHandler
-	jobHandler         *handler.JobHandler
-	applicationHandler *handler.ApplicationHandler
+	config              *config.Config
+	logger              *zap.Logger
+	router              *gin.Engine
+	userHandler         *handler.UserHandler
+	jobHandler          *handler.JobHandler
+	jobTemplateHandler  *handler.JobTemplateHandler
+	applicationHandler  *handler.ApplicationHandler
+	moderationHandler   *handler.ModerationHandler
+	notificationHandler *handler.NotificationHandler
+	taskHandler         *handler.TaskHandler
+	skillHandler        *handler.SkillHandler
 }
 
 func NewServer(
@@ -28,15 +42,30 @@ func NewServer(
 	logger *zap.Logger,
 	userService *service.UserService,
 	jobService *service.JobService,
+	jobTemplateService *service.JobTemplateService,
 	applicationService *service.ApplicationService,
+	notificationService *service.NotificationService,
+	engagementService *service.EngagementService,
+	taskRunner *tasks.Runner,
+	taxonomy *skills.Taxonomy,
 ) *Server {
+	postings := &schemaorg.Builder{
+		SiteName: cfg.SiteName,
+		SiteURL:  cfg.SiteURL,
+		Currency: cfg.SalaryCurrency,
+	}
 	server := &Server{
-		config:             cfg,
-		logger:             logger,
-		router:             gin.New(),
-		userHandler:        handler.NewUserHandler(userService),
-		jobHandler:         handler.NewJobHandler(jobService),
-		applicationHandler: handler.NewApplicationHandler(applicationService),
+		config:              cfg,
+		logger:              logger,
+		router:              gin.New(),
+		userHandler:         handler.NewUserHandler(userService),
+		jobHandler:          handler.NewJobHandler(jobService, jobTemplateService, engagementService, taskRunner, postings),
+		jobTemplateHandler:  handler.NewJobTemplateHandler(jobTemplateService),
+		applicationHandler:  handler.NewApplicationHandler(applicationService, engagementService),
+		moderationHandler:   handler.NewModerationHandler(jobService, userService),
+		notificationHandler: handler.NewNotificationHandler(notificationService),
+		taskHandler:         handler.NewTaskHandler(taskRunner),
+		skillHandler:        handler.NewSkillHandler(taxonomy),
 	}
 	server.setupRouter()
 	return server
@@ -62,8 +91,18 @@ func (s *Server) setupRouter() {
 	// Public routes
 	s.router.POST("/api/v1/users/register", s.userHandler.Register)
 	s.router.POST("/api/v1/users/login", s.userHandler.Login)
-	s.router.GET("/api/v1/jobs", s.jobHandler.List)
-	s.router.GET("/api/v1/jobs/:id", s.jobHandler.Get)
+	optionalAuth := middleware.OptionalAuth(s.config.JWTSecret)
+	s.router.GET("/api/v1/jobs", optionalAuth, s.jobHandler.List)
+	s.router.GET("/api/v1/jobs/:id", optionalAuth, s.jobHandler.Get)
+	s.router.GET("/api/v1/jobs/:id/similar", optionalAuth, s.jobHandler.Similar)
+	s.router.GET("/api/v1/jobs/:id/apply", optionalAuth, s.jobHandler.Apply)
+	s.router.GET("/api/v1/jobs/:id/apply/track", optionalAuth, s.jobHandler.TrackApply)
+	s.router.GET("/api/v1/users/:id", optionalAuth, s.userHandler.GetProfile)
+	s.router.GET("/api/v1/skills", s.skillHandler.Suggest)
+	s.router.GET("/sitemap.xml", s.jobHandler.Sitemap)
+	s.router.GET("/feeds/jobs.rss", s.jobHandler.RSSFeed)
+	s.router.GET("/feeds/jobs.atom", s.jobHandler.AtomFeed)
+	s.router.GET("/feeds/indeed.xml", s.jobHandler.IndeedFeed)
 
 	// Protected routes
 	auth := s.router.Group("/api/v1")
@@ -75,12 +114,33 @@ func (s *Server) setupRouter() {
 		{
 			recruiter.POST("/jobs", s.jobHandler.Create)
 			recruiter.PUT("/jobs/:id", s.jobHandler.Update)
+			recruiter.PATCH("/jobs/:id", s.jobHandler.Patch)
 			recruiter.PATCH("/jobs/:id/status", s.jobHandler.ChangeStatus)
-			recruiter.DELETE("/jobs/:id", s.jobHandler.CompleteDelete)
+			recruiter.POST("/jobs/:id/renew", s.jobHandler.Renew)
+			recruiter.GET("/jobs/:id/revisions", s.jobHandler.ListRevisions)
+			recruiter.GET("/jobs/:id/revisions/diff", s.jobHandler.DiffRevisions)
+			recruiter.POST("/jobs/:id/revisions/:rev/restore", s.jobHandler.RestoreRevision)
+			recruiter.DELETE("/jobs/:id", s.jobHandler.Delete)
+			recruiter.GET("/jobs/trash", s.jobHandler.Trash)
+			recruiter.POST("/jobs/:id/restore", s.jobHandler.Restore)
+			recruiter.POST("/jobs/:id/clone", s.jobHandler.Clone)
+			recruiter.POST("/jobs/from-template/:tid", s.jobHandler.CreateFromTemplate)
+			recruiter.POST("/job-templates", s.jobTemplateHandler.Create)
+			recruiter.GET("/job-templates", s.jobTemplateHandler.List)
+			recruiter.GET("/job-templates/:tid", s.jobTemplateHandler.Get)
+			recruiter.DELETE("/job-templates/:tid", s.jobTemplateHandler.Delete)
 			recruiter.GET("/jobs/analytics", s.jobHandler.GetJobAnalytics)
+			recruiter.GET("/jobs/analytics/dashboard", s.applicationHandler.Dashboard)
 			recruiter.POST("/jobs/bulk", s.jobHandler.BulkCreateJobs)
+			recruiter.POST("/jobs/bulk/status", s.jobHandler.BulkChangeStatus)
+			recruiter.POST("/jobs/bulk/close", s.jobHandler.BulkClose)
+			recruiter.POST("/jobs/import", s.jobHandler.Import)
+			recruiter.GET("/jobs/:id/funnel", s.jobHandler.Funnel)
 			recruiter.GET("/jobs/:id/application-insights", s.jobHandler.GetJobApplicationInsights)
 			recruiter.GET("/jobs/:id/recommended-candidates", s.jobHandler.GetRecommendedCandidates)
+			recruiter.GET("/candidates", s.userHandler.SearchCandidates)
+			recruiter.GET("/candidates/:id", s.userHandler.ViewCandidate)
+			recruiter.PATCH("/applications/:id/status", s.applicationHandler.ChangeStatus)
 		}
 
 		// Job seeker routes
@@ -89,13 +149,66 @@ func (s *Server) setupRouter() {
 		{
 			jobSeeker.POST("/applications", s.applicationHandler.Create)
 			jobSeeker.GET("/applications", s.applicationHandler.List)
+			jobSeeker.GET("/users/me/recommended-jobs", s.jobHandler.RecommendedJobs)
+			jobSeeker.GET("/users/me/privacy", s.userHandler.GetPrivacy)
+			jobSeeker.PUT("/users/me/privacy", s.userHandler.UpdatePrivacy)
+			jobSeeker.POST("/users/me/blocked-companies", s.userHandler.BlockCompany)
+			jobSeeker.DELETE("/users/me/blocked-companies/:id", s.userHandler.UnblockCompany)
+			jobSeeker.POST("/jobs/:id/not-interested", s.jobHandler.NotInterested)
+			jobSeeker.DELETE("/jobs/:id/not-interested", s.jobHandler.ClearNotInterested)
+		}
+
+		// Admin routes
+		admin := auth.Group("/admin")
+		admin.Use(middleware.RequireRole(s.config.AdminRole))
+		{
+			admin.GET("/jobs/moderation", s.moderationHandler.Queue)
+			admin.POST("/jobs/:id/approve", s.moderationHandler.Approve)
+			admin.POST("/jobs/:id/reject", s.moderationHandler.Reject)
+			admin.GET("/jobs/duplicates", s.moderationHandler.DuplicateReport)
+			admin.POST("/users/:id/verify", s.moderationHandler.VerifyCompany)
+			admin.POST("/users/:id/restore", s.moderationHandler.RestoreUser)
 		}
 
+		auth.GET("/users/me", s.userHandler.GetMe)
+		auth.GET("/users/me/analytics", s.userHandler.GetAnalytics)
 		auth.PUT("/users/profile", s.userHandler.UpdateProfileDetails)
+		auth.DELETE("/users/me", s.userHandler.DeleteAccount)
 		auth.PUT("/users/employment-history", s.userHandler.UpdateEmploymentHistory)
+		auth.GET("/notifications", s.notificationHandler.List)
+		auth.POST("/notifications/:id/read", s.notificationHandler.MarkRead)
+		auth.GET("/tasks/:id", s.taskHandler.Get)
+		auth.POST("/tasks/:id/cancel", s.taskHandler.Cancel)
 	}
 }
 
-func (s *Server) Run() error {
-	return s.router.Run(":" + s.config.Port)
+// Run serves requests until ctx is cancelled, then stops accepting new ones
+// and waits for those in flight to finish.
+func (s *Server) Run(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:    ":" + s.config.Port,
+		Handler: s.router,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.logger.Info("shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/zahidhasann88/job-board-api/pkg/validator"
@@ -17,9 +18,59 @@ type Config struct {
 	FileStoragePath            string
 	RecruiterRole              string
 	JobSeekerRole              string
+	AdminRole                  string
 	AllowedPorts               []string
 	RateLimitRequestsPerMinute int
 	RateLimitBurstRequestCount int
+
+	// Job moderation: "off", "unverified" (unverified or new companies) or "all"
+	JobModerationMode        string
+	ModerationNewCompanyDays int
+
+	// JSON file with fraud screening rules; built-in defaults when empty
+	ScreeningRulesPath string
+
+	// JSON file with the skill taxonomy; built-in defaults when empty
+	SkillTaxonomyPath string
+
+	// Near-duplicate postings: "off", "warn" or "reject"
+	DuplicateJobPolicy    string
+	DuplicateJobThreshold float64
+
+	// Background scheduler for publishing and expiring jobs
+	SchedulerInterval  time.Duration
+	ExpiryReminderDays int
+	JobRenewalDays     int
+
+	// Days deleted jobs and users stay restorable before being purged
+	DeletedRetentionDays int
+
+	// Public site whose job pages (SITE_URL/jobs/<id>) are indexed by search
+	// engines and linked from feeds, and the currency of salaries that don't
+	// name one
+	SiteName       string
+	SiteURL        string
+	SalaryCurrency string
+
+	// Key for anonymizing visitors in apply click and engagement tracking
+	TrackingSecret string
+
+	// Engagement events queued in memory and how often they are written out
+	EngagementBufferSize    int
+	EngagementFlushInterval time.Duration
+
+	// Weights of the factors used to match candidates to jobs and jobs to seekers
+	MatchWeightSkills       float64
+	MatchWeightExperience   float64
+	MatchWeightLocation     float64
+	MatchWeightCompleteness float64
+	MatchWeightHistory      float64
+
+	// Workers running background tasks such as bulk job operations
+	TaskWorkers int
+
+	// text/template for notices sent to applicants auto-rejected once a job is filled
+	PositionFilledTemplate string
 }
 
 func LoadConfig() (*Config, error) {
@@ -32,9 +83,35 @@ func LoadConfig() (*Config, error) {
 		FileStoragePath:            getEnv("FILE_STORAGE_PATH", "./uploads"),
 		RecruiterRole:              getEnv("RECRUITER_ROLE", "recruiter"),
 		JobSeekerRole:              getEnv("JOB_SEEKER_ROLE", "job_seeker"),
+		AdminRole:                  getEnv("ADMIN_ROLE", "admin"),
 		AllowedPorts:               strings.Split(getEnv("ALLOWED_PORTS", "8080"), ","),
 		RateLimitRequestsPerMinute: getEnvAsInt("RATE_LIMIT_REQUESTS_PER_MINUTE", 100),
 		RateLimitBurstRequestCount: getEnvAsInt("RATE_LIMIT_BURST_COUNT", 50),
+		JobModerationMode:          getEnv("JOB_MODERATION_MODE", "unverified"),
+		ModerationNewCompanyDays:   getEnvAsInt("MODERATION_NEW_COMPANY_DAYS", 30),
+		ScreeningRulesPath:         getEnv("SCREENING_RULES_PATH", ""),
+		SkillTaxonomyPath:          getEnv("SKILL_TAXONOMY_PATH", ""),
+		DuplicateJobPolicy:         getEnv("DUPLICATE_JOB_POLICY", "warn"),
+		DuplicateJobThreshold:      getEnvAsFloat("DUPLICATE_JOB_THRESHOLD", 0.7),
+		SchedulerInterval:          getEnvAsDuration("SCHEDULER_INTERVAL", time.Minute),
+		ExpiryReminderDays:         getEnvAsInt("JOB_EXPIRY_REMINDER_DAYS", 3),
+		JobRenewalDays:             getEnvAsInt("JOB_RENEWAL_DAYS", 30),
+		DeletedRetentionDays:       getEnvAsInt("DELETED_RETENTION_DAYS", 30),
+		TaskWorkers:                getEnvAsInt("TASK_WORKERS", 2),
+		MatchWeightSkills:          getEnvAsFloat("MATCH_WEIGHT_SKILLS", 0.5),
+		MatchWeightExperience:      getEnvAsFloat("MATCH_WEIGHT_EXPERIENCE", 0.25),
+		MatchWeightLocation:        getEnvAsFloat("MATCH_WEIGHT_LOCATION", 0.15),
+		MatchWeightCompleteness:    getEnvAsFloat("MATCH_WEIGHT_COMPLETENESS", 0.1),
+		MatchWeightHistory:         getEnvAsFloat("MATCH_WEIGHT_HISTORY", 0.2),
+		TrackingSecret:             getEnv("TRACKING_SECRET", "your-tracking-secret"),
+		EngagementBufferSize:       getEnvAsInt("ENGAGEMENT_BUFFER_SIZE", 10000),
+		EngagementFlushInterval:    getEnvAsDuration("ENGAGEMENT_FLUSH_INTERVAL", 5*time.Second),
+		SiteName:                   getEnv("SITE_NAME", "Job Board"),
+		SiteURL:                    getEnv("SITE_URL", "http://localhost:8080"),
+		SalaryCurrency:             getEnv("SALARY_CURRENCY", "USD"),
+		PositionFilledTemplate: getEnv("POSITION_FILLED_TEMPLATE",
+			"Thank you for applying to {{.JobTitle}}. All positions for this role have now been filled, "+
+				"so we won't be moving forward with your application. We wish you the best in your search."),
 	}
 
 	// Validate database URL
@@ -69,3 +146,29 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return value
 }
+
+// Helper function to get env as float with default
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// Helper function to get env as duration (e.g. "30s", "5m") with default
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := time.ParseDuration(valueStr)
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -6,6 +6,17 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	ApplicationStatusPending     = "pending"
+	ApplicationStatusReviewed    = "reviewed"
+	ApplicationStatusInterviewed = "interviewed"
+	ApplicationStatusAccepted    = "accepted"
+	ApplicationStatusRejected    = "rejected"
+)
+
+// ApplicationSourceDirect is the source of applications that don't name one.
+const ApplicationSourceDirect = "direct"
+
 type Application struct {
 	ID          uuid.UUID `json:"id"`
 	JobID       uuid.UUID `json:"job_id"`
@@ -13,6 +24,7 @@ type Application struct {
 	CoverLetter string    `json:"cover_letter"`
 	ResumeURL   string    `json:"resume_url"`
 	Status      string    `json:"status"`
+	Source      string    `json:"source"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -23,4 +35,8 @@ type ApplicationFilter struct {
 	Status      *string
 	Page        int
 	PageSize    int
-}
\ No newline at end of file
+}
+
+type UpdateApplicationStatusRequest struct {
+	Status string `json:"status" binding:"required" validate:"application_status"`
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dashboard intervals
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// Dashboard groupings
+const (
+	GroupByJob      = "job"
+	GroupByLocation = "location"
+	GroupByJobType  = "job_type"
+)
+
+// ApplicationActivity is an application with the milestones of its status
+// history that the recruiter dashboard is built from.
+type ApplicationActivity struct {
+	ApplicationID uuid.UUID
+	JobID         uuid.UUID
+	JobTitle      string
+	Location      string
+	JobType       string
+	Source        string
+	CreatedAt     time.Time
+	FirstReviewAt *time.Time
+	HiredAt       *time.Time
+	// Every status the application has been in
+	Statuses []string
+}
+
+type DashboardQuery struct {
+	From     time.Time
+	To       time.Time
+	Interval string
+	GroupBy  string
+}
+
+// DurationStats summarizes how long a pipeline step took, in the unit named
+// by the field holding it.
+type DurationStats struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	Median  float64 `json:"median"`
+}
+
+// StageConversion is how many applications reached a stage and what share of
+// those at the stage before it did.
+type StageConversion struct {
+	Stage        string  `json:"stage"`
+	Applications int     `json:"applications"`
+	Rate         float64 `json:"rate"`
+}
+
+type ApplicationMetrics struct {
+	Applications      int               `json:"applications"`
+	Hires             int               `json:"hires"`
+	TimeToFirstReview DurationStats     `json:"time_to_first_review_hours"`
+	TimeToHire        DurationStats     `json:"time_to_hire_days"`
+	Sources           map[string]int    `json:"sources"`
+	Stages            []StageConversion `json:"stages"`
+}
+
+// MetricsChange is the relative change of each metric from the previous
+// period, e.g. 0.25 for a quarter more. It is nil when the previous period
+// had nothing to compare with.
+type MetricsChange struct {
+	Applications      *float64 `json:"applications"`
+	Hires             *float64 `json:"hires"`
+	TimeToFirstReview *float64 `json:"time_to_first_review_hours"`
+	TimeToHire        *float64 `json:"time_to_hire_days"`
+}
+
+type DashboardPoint struct {
+	Period       string `json:"period"`
+	Group        string `json:"group,omitempty"`
+	Label        string `json:"label,omitempty"`
+	Applications int    `json:"applications"`
+	Hires        int    `json:"hires"`
+}
+
+type DashboardGroup struct {
+	Key      string             `json:"key"`
+	Label    string             `json:"label"`
+	Current  ApplicationMetrics `json:"current"`
+	Previous ApplicationMetrics `json:"previous"`
+	Change   MetricsChange      `json:"change"`
+}
+
+type ApplicationDashboard struct {
+	From         string             `json:"from"`
+	To           string             `json:"to"`
+	PreviousFrom string             `json:"previous_from"`
+	PreviousTo   string             `json:"previous_to"`
+	Interval     string             `json:"interval"`
+	GroupBy      string             `json:"group_by,omitempty"`
+	Current      ApplicationMetrics `json:"current"`
+	Previous     ApplicationMetrics `json:"previous"`
+	Change       MetricsChange      `json:"change"`
+	Series       []DashboardPoint   `json:"series"`
+	Groups       []DashboardGroup   `json:"groups,omitempty"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplyClick is a click through to an external job's apply URL. VisitorHash
+// is a keyed hash of who clicked, so repeat clicks can be told apart without
+// storing the visitor.
+type ApplyClick struct {
+	ID          uuid.UUID `json:"id"`
+	JobID       uuid.UUID `json:"job_id"`
+	Referrer    *string   `json:"referrer,omitempty"`
+	VisitorHash string    `json:"visitor_hash"`
+	CreatedAt   time.Time `json:"created_at"`
+}
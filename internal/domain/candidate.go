@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// CandidateSearch filters job seekers for recruiters. Every filter that is
+// set must match.
+type CandidateSearch struct {
+	// Full-text query over the bio, experience and employment history
+	Query    string
+	Skills   []string
+	Location *string
+	// Years of experience from the employment history
+	MinYears *float64
+	MaxYears *float64
+	// Names of certifications the candidate must hold, matched in part
+	Certifications []string
+	OpenToWork     *bool
+}
+
+// CandidateSummary is a job seeker as listed in search results.
+type CandidateSummary struct {
+	UserID            uuid.UUID `json:"user_id"`
+	FullName          string    `json:"full_name"`
+	Bio               *string   `json:"bio,omitempty"`
+	Location          *string   `json:"location,omitempty"`
+	Skills            []string  `json:"skills"`
+	CurrentTitle      *string   `json:"current_title,omitempty"`
+	Certifications    []string  `json:"certifications"`
+	OpenToWork        bool      `json:"open_to_work"`
+	YearsOfExperience float64   `json:"years_of_experience"`
+	// How well the profile matches the query; 0 without a query
+	Relevance         float64             `json:"relevance"`
+	EmploymentHistory []EmploymentHistory `json:"-"`
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Engagement events, in funnel order
+const (
+	EngagementImpression    = "impression"
+	EngagementView          = "view"
+	EngagementApplyStart    = "apply_start"
+	EngagementApplyComplete = "apply_complete"
+)
+
+// EngagementEvent is a visitor's interaction with a job. Each visitor is
+// counted at most once per job, event and day.
+type EngagementEvent struct {
+	JobID       uuid.UUID
+	Event       string
+	VisitorHash string
+	Day         time.Time
+}
+
+// EngagementCount is the number of distinct visitors with an event on a day.
+type EngagementCount struct {
+	Day      time.Time
+	Event    string
+	Visitors int
+}
+
+type FunnelDay struct {
+	Date           string `json:"date,omitempty"`
+	Impressions    int    `json:"impressions"`
+	Views          int    `json:"views"`
+	ApplyStarts    int    `json:"apply_starts"`
+	ApplyCompletes int    `json:"apply_completes"`
+}
+
+// FunnelRates are the share of visitors reaching each step from the one
+// before it; Overall runs from impression to completed application.
+type FunnelRates struct {
+	ViewRate       float64 `json:"view_rate"`
+	ApplyStartRate float64 `json:"apply_start_rate"`
+	CompletionRate float64 `json:"completion_rate"`
+	Overall        float64 `json:"overall"`
+}
+
+type JobFunnel struct {
+	JobID      uuid.UUID   `json:"job_id"`
+	From       string      `json:"from"`
+	To         string      `json:"to"`
+	Days       []FunnelDay `json:"days"`
+	Totals     FunnelDay   `json:"totals"`
+	Conversion FunnelRates `json:"conversion"`
+}
@@ -6,50 +6,141 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	JobStatusDraft         = "draft"
+	JobStatusPendingReview = "pending_review"
+	JobStatusActive        = "active"
+	JobStatusInactive      = "inactive"
+	JobStatusClosed        = "closed"
+	JobStatusRejected      = "rejected"
+)
+
+// How candidates apply: through the board, or on the company's own site at
+// the job's apply URL
+const (
+	ApplyMethodInternal = "internal"
+	ApplyMethodExternal = "external"
+)
+
 type Job struct {
-	ID              uuid.UUID `json:"id"`
-	Title           string    `json:"title"`
-	Description     string    `json:"description"`
-	CompanyID       uuid.UUID `json:"company_id"`
-	Location        string    `json:"location"`
-	SalaryRange     *string   `json:"salary_range,omitempty"`
-	JobType         string    `json:"job_type"`
-	ExperienceLevel string    `json:"experience_level"`
-	Skills          []string  `json:"skills"`
-	Status          string    `json:"status"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              uuid.UUID  `json:"id"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	CompanyID       uuid.UUID  `json:"company_id"`
+	Location        string     `json:"location"`
+	SalaryRange     *string    `json:"salary_range,omitempty"`
+	JobType         string     `json:"job_type"`
+	ExperienceLevel string     `json:"experience_level"`
+	Skills          []string   `json:"skills"`
+	Status          string     `json:"status"`
+	ModerationNote  *string    `json:"moderation_note,omitempty"`
+	ReviewedBy      *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Version is bumped on every write and served as the job's ETag
+	Version int `json:"version"`
+
+	// DeletedAt is set while the job is in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Headcount: the job closes once Openings applications are accepted,
+	// optionally rejecting the applications still open
+	Openings            int  `json:"openings"`
+	AutoRejectRemaining bool `json:"auto_reject_remaining"`
+
+	// External jobs take applications at ApplyURL instead of on the board
+	ApplyMethod string  `json:"apply_method"`
+	ApplyURL    *string `json:"apply_url,omitempty"`
+
+	// Scheduling: drafts with a publish_at go live at that time and active
+	// jobs are closed once expires_at passes
+	PublishAt            *time.Time `json:"publish_at,omitempty"`
+	PublishedAt          *time.Time `json:"published_at,omitempty"`
+	ExpiresAt            *time.Time `json:"expires_at,omitempty"`
+	ApplicationDeadline  *time.Time `json:"application_deadline,omitempty"`
+	ExpiryReminderSentAt *time.Time `json:"-"`
+
+	// Fraud screening results are only shown to moderators
+	RiskScore   *float64 `json:"-"`
+	RiskReasons []string `json:"-"`
+
+	// Set at creation time when near-duplicates of the posting already exist
+	PossibleDuplicates []uuid.UUID `json:"possible_duplicates,omitempty"`
+}
+
+// DuplicateCluster is a group of postings whose content is nearly identical.
+type DuplicateCluster struct {
+	Similarity float64 `json:"similarity"`
+	Jobs       []Job   `json:"jobs"`
 }
 
+// ModerationQueueItem is a job awaiting review together with the fraud
+// screening results that put it there.
+type ModerationQueueItem struct {
+	Job
+	RiskScore   *float64 `json:"risk_score,omitempty"`
+	RiskReasons []string `json:"risk_reasons,omitempty"`
+}
+
+// JobFilter selects jobs for the job list. Bulk operations accept it as JSON
+// to select a company's jobs; the company and paging fields are not exposed.
 type JobFilter struct {
-	Location        *string
-	JobType         *string
-	ExperienceLevel *string
-	Skills          []string
-	CompanyID       *uuid.UUID
-	Status          *string
-	Page            int
-	PageSize        int
+	Location        *string    `json:"location,omitempty"`
+	JobType         *string    `json:"job_type,omitempty"`
+	ExperienceLevel *string    `json:"experience_level,omitempty"`
+	Skills          []string   `json:"skills,omitempty"`
+	CompanyID       *uuid.UUID `json:"-"`
+	Status          *string    `json:"status,omitempty"`
+	OldestFirst     bool       `json:"-"`
+	Page            int        `json:"-"`
+	PageSize        int        `json:"-"`
+}
+
+type ReviewJobRequest struct {
+	Reason string `json:"reason"`
+	// Fraud marks a rejection as fraud rather than a policy or quality issue
+	Fraud bool `json:"fraud"`
 }
+
 type CreateJobRequest struct {
-	Title           string   `json:"title" binding:"required"`
-	Description     string   `json:"description" binding:"required"`
-	Location        string   `json:"location" binding:"required"`
-	SalaryRange     *string  `json:"salary_range"`
-	JobType         string   `json:"job_type" binding:"required"`
-	ExperienceLevel string   `json:"experience_level" binding:"required"`
-	Skills          []string `json:"skills" binding:"required"`
+	Title               string     `json:"title" binding:"required" validate:"required"`
+	Description         string     `json:"description" binding:"required" validate:"required"`
+	Location            string     `json:"location" binding:"required" validate:"required"`
+	SalaryRange         *string    `json:"salary_range"`
+	JobType             string     `json:"job_type" binding:"required" validate:"required,job_type"`
+	ExperienceLevel     string     `json:"experience_level" binding:"required" validate:"required,experience_level"`
+	Skills              []string   `json:"skills" binding:"required" validate:"required"`
+	Openings            int        `json:"openings" validate:"min=0"`
+	AutoRejectRemaining bool       `json:"auto_reject_remaining"`
+	ApplyMethod         string     `json:"apply_method"`
+	ApplyURL            *string    `json:"apply_url"`
+	PublishAt           *time.Time `json:"publish_at"`
+	ExpiresAt           *time.Time `json:"expires_at"`
+	ApplicationDeadline *time.Time `json:"application_deadline"`
 }
 
 type UpdateJobRequest struct {
-	Title           string   `json:"title"`
-	Description     string   `json:"description"`
-	Location        string   `json:"location"`
-	SalaryRange     *string  `json:"salary_range"`
-	JobType         string   `json:"job_type"`
-	ExperienceLevel string   `json:"experience_level"`
-	Skills          []string `json:"skills"`
-	Status          string   `json:"status"`
+	Title               string     `json:"title"`
+	Description         string     `json:"description"`
+	Location            string     `json:"location"`
+	SalaryRange         *string    `json:"salary_range"`
+	JobType             string     `json:"job_type"`
+	ExperienceLevel     string     `json:"experience_level"`
+	Skills              []string   `json:"skills"`
+	Status              string     `json:"status"`
+	Openings            int        `json:"openings"`
+	AutoRejectRemaining bool       `json:"auto_reject_remaining"`
+	ApplyMethod         string     `json:"apply_method"`
+	ApplyURL            *string    `json:"apply_url"`
+	PublishAt           *time.Time `json:"publish_at"`
+	ExpiresAt           *time.Time `json:"expires_at"`
+	ApplicationDeadline *time.Time `json:"application_deadline"`
+}
+
+type RenewJobRequest struct {
+	Days int `json:"days"`
 }
 
 type JobAnalytics struct {
@@ -59,21 +150,37 @@ type JobAnalytics struct {
 }
 
 type JobApplicationInsights struct {
-    JobID                  uuid.UUID `json:"job_id"`
-    TotalApplications      int       `json:"total_applications"`
-    PendingApplications    int       `json:"pending_applications"`
-    ReviewedApplications   int       `json:"reviewed_applications"`
-    InterviewedApplications int      `json:"interviewed_applications"`
-    AcceptedApplications   int       `json:"accepted_applications"`
-    RejectedApplications   int       `json:"rejected_applications"`
-    AverageExperience     float64   `json:"average_experience"`
+    JobID                uuid.UUID       `json:"job_id"`
+    TotalApplications    int             `json:"total_applications"`
+    ApplicationsByStatus map[string]int  `json:"applications_by_status"`
+    Experience           ExperienceStats `json:"experience"`
+    SkillCoverage        []SkillCoverage `json:"skill_coverage"`
+    EducationLevels      map[string]int  `json:"education_levels"`
+    Openings             int             `json:"openings"`
+    FilledPositions      int             `json:"filled_positions"`
+    OpenPositions        int             `json:"open_positions"`
+}
+
+// ExperienceStats summarizes applicants' years of work from their employment
+// history. Applicants without any history are left out.
+type ExperienceStats struct {
+    Applicants   int     `json:"applicants"`
+    AverageYears float64 `json:"average_years"`
+    MedianYears  float64 `json:"median_years"`
 }
 
-type RecommendedCandidate struct {
-    UserID              uuid.UUID `json:"user_id"`
-    Name                string    `json:"name"`
-    Email               string    `json:"email"`
-    YearsOfExperience   int       `json:"years_of_experience"`
-    Skills              []string  `json:"skills"`
-    SkillMatchPercentage float64  `json:"skill_match_percentage"`
-}
\ No newline at end of file
+// SkillCoverage is how many applicants list one of the job's skills.
+type SkillCoverage struct {
+    Skill      string  `json:"skill"`
+    Applicants int     `json:"applicants"`
+    Share      float64 `json:"share"`
+}
+
+// ApplicantBackground is what an applicant's profile says about their
+// skills, work and education.
+type ApplicantBackground struct {
+    UserID     uuid.UUID
+    Skills     []string
+    Employment []EmploymentHistory
+    Degrees    []string
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "github.com/google/uuid"
+
+const (
+	// ImportModeAllOrNothing creates no jobs unless every row is valid
+	ImportModeAllOrNothing = "all_or_nothing"
+	// ImportModeBestEffort creates the valid rows and reports the rest
+	ImportModeBestEffort = "best_effort"
+)
+
+const (
+	ImportRowCreated = "created"
+	ImportRowValid   = "valid"
+	ImportRowSkipped = "skipped"
+	ImportRowError   = "error"
+)
+
+// JobImportOptions controls a spreadsheet import. Mapping maps job fields to
+// the spreadsheet's column headers; unmapped fields use their own name.
+type JobImportOptions struct {
+	Mapping map[string]string
+	DryRun  bool
+	Mode    string
+}
+
+type ImportFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// JobImportRow is one spreadsheet row read into a job request. Line is the
+// row's line number in the file, counting the header as line 1.
+type JobImportRow struct {
+	Line    int
+	Blank   bool
+	Request CreateJobRequest
+	Errors  []ImportFieldError
+}
+
+type JobImportRowResult struct {
+	Line    int                `json:"line"`
+	Status  string             `json:"status"`
+	JobID   *uuid.UUID         `json:"job_id,omitempty"`
+	Message string             `json:"message,omitempty"`
+	Errors  []ImportFieldError `json:"errors,omitempty"`
+}
+
+type JobImportReport struct {
+	Mode    string               `json:"mode"`
+	DryRun  bool                 `json:"dry_run"`
+	Total   int                  `json:"total"`
+	Created int                  `json:"created"`
+	Skipped int                  `json:"skipped"`
+	Failed  int                  `json:"failed"`
+	Rows    []JobImportRowResult `json:"rows"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RevisionCreated       = "created"
+	RevisionUpdated       = "updated"
+	RevisionStatusChanged = "status_changed"
+	RevisionReviewed      = "reviewed"
+	RevisionRenewed       = "renewed"
+	RevisionRestored      = "restored"
+	RevisionUndeleted     = "undeleted"
+	RevisionUnscheduled   = "unscheduled"
+	// Skills rewritten to their canonical names by the backfill
+	RevisionSkillsNormalized = "skills_normalized"
+)
+
+// JobRevision is an immutable snapshot of a job taken after every change.
+// ChangedBy is empty for changes made by the system, such as the scheduler.
+type JobRevision struct {
+	ID         uuid.UUID  `json:"id"`
+	JobID      uuid.UUID  `json:"job_id"`
+	Revision   int        `json:"revision"`
+	ChangeType string     `json:"change_type"`
+	ChangedBy  *uuid.UUID `json:"changed_by,omitempty"`
+	Snapshot   Job        `json:"snapshot"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type JobRevisionDiff struct {
+	JobID   uuid.UUID     `json:"job_id"`
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobTemplate is a reusable job posting owned by a company. Text fields may
+// contain {{name}} placeholders that are filled in when a job is created
+// from the template.
+type JobTemplate struct {
+	ID              uuid.UUID `json:"id"`
+	CompanyID       uuid.UUID `json:"company_id"`
+	Name            string    `json:"name"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	Location        string    `json:"location"`
+	SalaryRange     *string   `json:"salary_range,omitempty"`
+	JobType         string    `json:"job_type"`
+	ExperienceLevel string    `json:"experience_level"`
+	Skills          []string  `json:"skills"`
+	Openings        int       `json:"openings"`
+	Placeholders    []string  `json:"placeholders"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// CreateJobTemplateRequest creates a template either from the given fields
+// or, when JobID is set, from one of the company's existing jobs.
+type CreateJobTemplateRequest struct {
+	Name            string     `json:"name" binding:"required"`
+	JobID           *uuid.UUID `json:"job_id"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Location        string     `json:"location"`
+	SalaryRange     *string    `json:"salary_range"`
+	JobType         string     `json:"job_type"`
+	ExperienceLevel string     `json:"experience_level"`
+	Skills          []string   `json:"skills"`
+	Openings        int        `json:"openings"`
+}
+
+// JobFromTemplateRequest supplies placeholder values and the schedule for a
+// job created from a template.
+type JobFromTemplateRequest struct {
+	Values              map[string]string `json:"values"`
+	PublishAt           *time.Time        `json:"publish_at"`
+	ExpiresAt           *time.Time        `json:"expires_at"`
+	ApplicationDeadline *time.Time        `json:"application_deadline"`
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "strings"
+
+// IsRemoteLocation reports whether a job location means the job can be done
+// from anywhere, e.g. "Remote", "Anywhere" or "Remote (EU)".
+func IsRemoteLocation(location string) bool {
+	l := strings.ToLower(strings.TrimSpace(location))
+	return l == "remote" || l == "anywhere" || strings.HasPrefix(l, "remote ")
+}
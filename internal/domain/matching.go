@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Match factors
+const (
+	MatchFactorSkills        = "skills"
+	MatchFactorExperience    = "experience"
+	MatchFactorLocation      = "location"
+	MatchFactorCompleteness  = "profile_completeness"
+	MatchFactorHistory       = "application_history"
+	MatchFactorNotInterested = "not_interested"
+)
+
+// CandidateProfile is what matching knows about a job seeker.
+type CandidateProfile struct {
+	UserID              uuid.UUID
+	FullName            string
+	Email               string
+	Skills              []string
+	Location            *string
+	EmploymentHistory   []EmploymentHistory
+	ProfileCompleteness float64
+}
+
+// MatchFactor is one factor of a match score. Score runs from 0 to 1 and
+// Contribution is its share of the overall score, Score times Weight.
+type MatchFactor struct {
+	Factor       string  `json:"factor"`
+	Score        float64 `json:"score"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+	Explanation  string  `json:"explanation"`
+}
+
+// Match is how well a candidate and a job fit, from 0 to 100.
+type Match struct {
+	Score   float64       `json:"score"`
+	Factors []MatchFactor `json:"factors"`
+}
+
+// Factor returns the named factor of the match.
+func (m Match) Factor(name string) MatchFactor {
+	for _, f := range m.Factors {
+		if f.Factor == name {
+			return f
+		}
+	}
+	return MatchFactor{Factor: name}
+}
+
+type RecommendedCandidate struct {
+	UserID            uuid.UUID `json:"user_id"`
+	FullName          string    `json:"full_name"`
+	Email             string    `json:"email,omitempty"`
+	Location          *string   `json:"location,omitempty"`
+	Skills            []string  `json:"skills"`
+	YearsOfExperience float64   `json:"years_of_experience"`
+	Match
+}
+
+// YearsOfExperience adds up the time covered by an employment history.
+// Overlapping jobs are counted once and current jobs run until now.
+func YearsOfExperience(history []EmploymentHistory, now time.Time) float64 {
+	type span struct{ start, end time.Time }
+	spans := make([]span, 0, len(history))
+	for _, h := range history {
+		end := now
+		if h.EndDate != nil {
+			end = *h.EndDate
+		}
+		if end.After(h.StartDate) {
+			spans = append(spans, span{h.StartDate, end})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+	var total time.Duration
+	for i := 0; i < len(spans); {
+		current := spans[i]
+		for i++; i < len(spans) && !spans[i].start.After(current.end); i++ {
+			if spans[i].end.After(current.end) {
+				current.end = spans[i].end
+			}
+		}
+		total += current.end.Sub(current.start)
+	}
+	return total.Hours() / 24 / 365.25
+}
+
+type RecommendedJob struct {
+	Job Job `json:"job"`
+	Match
+}
+
+// SimilarJob is a job like another one. Similarity is the cosine of their
+// TF-IDF vectors, boosted for the same location and experience level.
+type SimilarJob struct {
+	Job        Job     `json:"job"`
+	Similarity float64 `json:"similarity"`
+}
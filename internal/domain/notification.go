@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationJobApproved = "job_approved"
+	NotificationJobRejected = "job_rejected"
+	NotificationJobExpiring = "job_expiring"
+	NotificationJobExpired  = "job_expired"
+	NotificationJobFilled   = "job_filled"
+	// A scheduled job couldn't be published and was taken off the schedule
+	NotificationJobNotPublished = "job_not_published"
+
+	NotificationApplicationStatus = "application_status"
+)
+
+type Notification struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Message   string     `json:"message"`
+	JobID     *uuid.UUID `json:"job_id,omitempty"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type NotificationFilter struct {
+	UserID     uuid.UUID
+	UnreadOnly bool
+	Page       int
+	PageSize   int
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TaskQueued    = "queued"
+	TaskRunning   = "running"
+	TaskSucceeded = "succeeded"
+	TaskFailed    = "failed"
+	TaskCancelled = "cancelled"
+)
+
+const (
+	TaskBulkCreateJobs = "bulk_create_jobs"
+	TaskBulkJobStatus  = "bulk_job_status"
+	TaskBulkCloseJobs  = "bulk_close_jobs"
+)
+
+// Task is a background operation run by the task runner. Processed counts
+// the items handled so far, including the Failed ones listed in Errors.
+type Task struct {
+	ID              uuid.UUID       `json:"id"`
+	OwnerID         uuid.UUID       `json:"owner_id"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	Total           int             `json:"total"`
+	Processed       int             `json:"processed"`
+	Failed          int             `json:"failed"`
+	Errors          []TaskItemError `json:"errors,omitempty"`
+	Result          json.RawMessage `json:"result,omitempty"`
+	Error           *string         `json:"error,omitempty"`
+	CancelRequested bool            `json:"cancel_requested"`
+	Payload         json.RawMessage `json:"-"`
+	CreatedAt       time.Time       `json:"created_at"`
+	StartedAt       *time.Time      `json:"started_at,omitempty"`
+	FinishedAt      *time.Time      `json:"finished_at,omitempty"`
+}
+
+// Finished reports whether the task has stopped for good.
+func (t *Task) Finished() bool {
+	return t.Status == TaskSucceeded || t.Status == TaskFailed || t.Status == TaskCancelled
+}
+
+// TaskItemError describes one item of a task that failed. Index is the
+// item's position in the request and Ref identifies it where possible.
+type TaskItemError struct {
+	Index   int    `json:"index"`
+	Ref     string `json:"ref,omitempty"`
+	Message string `json:"message"`
+}
+
+// BulkJobStatusRequest selects jobs either by ID or with a filter over the
+// company's jobs.
+type BulkJobStatusRequest struct {
+	JobIDs []uuid.UUID `json:"job_ids"`
+	Filter *JobFilter  `json:"filter"`
+	Status string      `json:"status" binding:"required"`
+}
+
+type BulkCloseJobsRequest struct {
+	JobIDs []uuid.UUID `json:"job_ids"`
+	Filter *JobFilter  `json:"filter"`
+}
+
+const (
+	JobOutcomeUpdated   = "updated"
+	JobOutcomeUnchanged = "unchanged"
+	JobOutcomeFailed    = "failed"
+)
+
+// JobStatusChange is a status change decided against a given version of a
+// job.
+type JobStatusChange struct {
+	JobID   uuid.UUID
+	Version int
+	Status  string
+}
+
+// JobStatusOutcome reports what a bulk status change did to one job.
+type JobStatusOutcome struct {
+	JobID   uuid.UUID `json:"job_id"`
+	Outcome string    `json:"outcome"`
+	From    string    `json:"from,omitempty"`
+	To      string    `json:"to,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
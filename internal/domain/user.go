@@ -14,6 +14,25 @@ const (
 	RoleAdmin     UserRole = "admin"
 )
 
+// Who can see a job seeker's profile
+const (
+	// Anyone, signed in or not
+	ProfileVisibilityPublic = "public"
+	// Recruiters only
+	ProfileVisibilityRecruiters = "recruiters"
+	// Only companies the job seeker applied to
+	ProfileVisibilityApplied = "applied"
+	// Nobody but the job seeker
+	ProfileVisibilityHidden = "hidden"
+)
+
+// Contact details a job seeker can hide from others
+const (
+	ContactFieldPhone   = "phone"
+	ContactFieldEmail   = "email"
+	ContactFieldAddress = "address"
+)
+
 type SocialLinks struct {
 	LinkedIn *string `json:"linkedin,omitempty"`
 	Twitter  *string `json:"twitter,omitempty"`
@@ -54,16 +73,17 @@ type Certification struct {
 }
 
 type User struct {
-	ID           uuid.UUID `json:"id"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"`
-	Role         UserRole  `json:"role"`
-	FullName     string    `json:"full_name"`
-	CompanyName  *string   `json:"company_name,omitempty"`
-	ResumeURL    *string   `json:"resume_url,omitempty"`
-	Verified     bool      `json:"verified"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uuid.UUID  `json:"id"`
+	Email        string     `json:"email"`
+	PasswordHash string     `json:"-"`
+	Role         UserRole   `json:"role"`
+	FullName     string     `json:"full_name"`
+	CompanyName  *string    `json:"company_name,omitempty"`
+	ResumeURL    *string    `json:"resume_url,omitempty"`
+	Verified     bool       `json:"verified"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
 
 	// Optional profile details
 	Skills            []string            `json:"skills,omitempty"`
@@ -77,6 +97,20 @@ type User struct {
 	EmploymentHistory []EmploymentHistory `json:"employment_history,omitempty"`
 	EducationHistory  []EducationHistory  `json:"education_history,omitempty"`
 	Certifications    []Certification     `json:"certifications,omitempty"`
+
+	// Privacy settings of job seekers, only shown to the job seeker
+	OpenToWork          bool     `json:"open_to_work"`
+	ProfileVisibility   string   `json:"profile_visibility,omitempty"`
+	HiddenContactFields []string `json:"-"`
+}
+
+// DisplayName returns the name a recruiter's jobs are published under: the
+// company name when set, otherwise the user's own name.
+func (u *User) DisplayName() string {
+	if u.CompanyName != nil && *u.CompanyName != "" {
+		return *u.CompanyName
+	}
+	return u.FullName
 }
 
 type UserAnalytics struct {
@@ -84,6 +118,34 @@ type UserAnalytics struct {
 	ProfileViews        int       `json:"profile_views"`
 	ProfileCompleteness float64   `json:"profile_completeness"`
 	LastActive          time.Time `json:"last_active"`
+
+	// Profile views between From and To, both inclusive
+	From            string           `json:"from"`
+	To              string           `json:"to"`
+	ViewsByDay      []ProfileViewDay `json:"views_by_day"`
+	ViewerCompanies []ProfileViewer  `json:"viewer_companies"`
+}
+
+// ProfileView is a visit to a user's profile. Each visitor is counted at most
+// once per profile and day. CompanyID is set when the visitor is a recruiter.
+type ProfileView struct {
+	UserID      uuid.UUID
+	Day         time.Time
+	VisitorHash string
+	CompanyID   *uuid.UUID
+}
+
+type ProfileViewDay struct {
+	Date  string `json:"date"`
+	Views int    `json:"views"`
+}
+
+// ProfileViewer is a company whose recruiters viewed a profile. Viewers are
+// only reported by company so the individual recruiters stay anonymous.
+type ProfileViewer struct {
+	Company      string    `json:"company"`
+	Views        int       `json:"views"`
+	LastViewedAt time.Time `json:"last_viewed_at"`
 }
 
 type RegisterRequest struct {
@@ -109,3 +171,30 @@ type UpdateProfileDetailsRequest struct {
 	SocialLinks       *SocialLinks `json:"social_links,omitempty"`
 	ContactInfo       *ContactInfo `json:"contact_info,omitempty"`
 }
+
+// PrivacySettings control who sees a job seeker's profile and what of it.
+type PrivacySettings struct {
+	ProfileVisibility   string           `json:"profile_visibility"`
+	HiddenContactFields []string         `json:"hidden_contact_fields"`
+	OpenToWork          bool             `json:"open_to_work"`
+	BlockedCompanies    []BlockedCompany `json:"blocked_companies"`
+}
+
+// BlockedCompany is a company that can't see a job seeker's profile, nor
+// any company with the same name.
+type BlockedCompany struct {
+	CompanyID uuid.UUID `json:"company_id"`
+	Name      string    `json:"name"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+// UpdatePrivacySettingsRequest changes the fields that are set.
+type UpdatePrivacySettingsRequest struct {
+	ProfileVisibility   *string  `json:"profile_visibility,omitempty"`
+	HiddenContactFields []string `json:"hidden_contact_fields,omitempty"`
+	OpenToWork          *bool    `json:"open_to_work,omitempty"`
+}
+
+type BlockCompanyRequest struct {
+	CompanyID uuid.UUID `json:"company_id" binding:"required"`
+}
@@ -0,0 +1,130 @@
+// Package engagement buffers job engagement events in memory and writes them
+// to the database in batches, away from the requests that produce them.
+package engagement
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+	"go.uber.org/zap"
+)
+
+// batchSize is the most events written in one transaction.
+const batchSize = 500
+
+// Recorder queues events and flushes them when a batch fills up or the flush
+// interval passes. Record never blocks: when the buffer is full the event is
+// dropped and counted instead.
+type Recorder struct {
+	repo     repository.EngagementRepository
+	logger   *zap.Logger
+	interval time.Duration
+	events   chan domain.EngagementEvent
+	dropped  atomic.Int64
+	done     chan struct{}
+}
+
+func NewRecorder(repo repository.EngagementRepository, logger *zap.Logger, bufferSize int, interval time.Duration) *Recorder {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &Recorder{
+		repo:     repo,
+		logger:   logger,
+		interval: interval,
+		events:   make(chan domain.EngagementEvent, bufferSize),
+		done:     make(chan struct{}),
+	}
+}
+
+// Record queues an event for the next flush.
+func (r *Recorder) Record(event domain.EngagementEvent) {
+	select {
+	case r.events <- event:
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// Start flushes queued events in the background until ctx is cancelled,
+// writing whatever is still queued on the way out.
+func (r *Recorder) Start(ctx context.Context) {
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		batch := newBatch()
+		for {
+			select {
+			case <-ctx.Done():
+				r.drain(batch)
+				r.flush(context.WithoutCancel(ctx), batch)
+				return
+			case e := <-r.events:
+				batch.add(e)
+				if len(batch.events) >= batchSize {
+					r.flush(ctx, batch)
+				}
+			case <-ticker.C:
+				r.flush(ctx, batch)
+			}
+		}
+	}()
+}
+
+// Wait blocks until the recorder has stopped and written the last events.
+func (r *Recorder) Wait() {
+	<-r.done
+}
+
+func (r *Recorder) drain(b *batch) {
+	for {
+		select {
+		case e := <-r.events:
+			b.add(e)
+		default:
+			return
+		}
+	}
+}
+
+func (r *Recorder) flush(ctx context.Context, b *batch) {
+	if n := r.dropped.Swap(0); n > 0 {
+		r.logger.Warn("dropped engagement events, buffer full", zap.Int64("count", n))
+	}
+	if len(b.events) == 0 {
+		return
+	}
+	if err := r.repo.InsertBatch(ctx, b.events); err != nil {
+		r.logger.Error("failed to write engagement events", zap.Int("count", len(b.events)), zap.Error(err))
+	}
+	b.reset()
+}
+
+// batch collects events, leaving out repeats that the database would ignore
+// anyway.
+type batch struct {
+	events []domain.EngagementEvent
+	seen   map[domain.EngagementEvent]struct{}
+}
+
+func newBatch() *batch {
+	return &batch{seen: make(map[domain.EngagementEvent]struct{})}
+}
+
+func (b *batch) add(e domain.EngagementEvent) {
+	if _, ok := b.seen[e]; ok {
+		return
+	}
+	b.seen[e] = struct{}{}
+	b.events = append(b.events, e)
+}
+
+func (b *batch) reset() {
+	b.events = b.events[:0]
+	clear(b.seen)
+}
@@ -0,0 +1,95 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+type atomWriter struct {
+	encoder
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     atomPerson     `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomText       `xml:"content"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (w *atomWriter) Begin(channel Channel) error {
+	if err := w.header(); err != nil {
+		return err
+	}
+	err := w.start("feed", xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: "http://www.w3.org/2005/Atom"})
+	if err != nil {
+		return err
+	}
+
+	// Atom requires an updated time even for an empty feed
+	updated := channel.Updated
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+
+	for _, field := range []struct {
+		v    interface{}
+		name string
+	}{
+		{channel.Self, "id"},
+		{channel.Title, "title"},
+		{channel.Description, "subtitle"},
+		{atomLink{Href: channel.Link}, "link"},
+		{atomLink{Href: channel.Self, Rel: "self", Type: "application/atom+xml"}, "link"},
+		{updated.UTC().Format(time.RFC3339), "updated"},
+	} {
+		if err := w.element(field.v, field.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *atomWriter) Job(job *domain.Job, company *domain.User) error {
+	entry := atomEntry{
+		ID:        "urn:uuid:" + job.ID.String(),
+		Title:     job.Title,
+		Link:      atomLink{Href: w.jobURL(job.ID), Rel: "alternate"},
+		Published: published(job).UTC().Format(time.RFC3339),
+		Updated:   job.UpdatedAt.UTC().Format(time.RFC3339),
+		Author:    atomPerson{Name: companyName(company)},
+		Content:   atomText{Type: "text", Value: job.Description},
+	}
+	for _, term := range append([]string{job.JobType}, job.Skills...) {
+		entry.Categories = append(entry.Categories, atomCategory{Term: term})
+	}
+	return w.element(entry, "entry")
+}
+
+func (w *atomWriter) End() error {
+	return w.end()
+}
@@ -0,0 +1,123 @@
+// Package feeds writes job listings as XML feeds for aggregators: RSS 2.0,
+// Atom and the Indeed XML format. Jobs are written one at a time so that
+// large feeds never have to be held in memory.
+package feeds
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+const (
+	FormatRSS    = "rss"
+	FormatAtom   = "atom"
+	FormatIndeed = "indeed"
+)
+
+var ErrUnknownFormat = errors.New("unknown feed format")
+
+// Channel describes the feed as a whole. Updated is the time of the newest
+// change to any job in it.
+type Channel struct {
+	Title       string
+	Description string
+	Link        string
+	Self        string
+	Updated     time.Time
+}
+
+// Writer writes one feed. Call Begin once, Job for every job and End to
+// finish the document.
+type Writer interface {
+	Begin(channel Channel) error
+	Job(job *domain.Job, company *domain.User) error
+	End() error
+}
+
+// ContentType returns the MIME type a feed format is served with.
+func ContentType(format string) string {
+	switch format {
+	case FormatRSS:
+		return "application/rss+xml; charset=utf-8"
+	case FormatAtom:
+		return "application/atom+xml; charset=utf-8"
+	default:
+		return "application/xml; charset=utf-8"
+	}
+}
+
+// NewWriter returns a writer for format that writes to w. JobURL returns the
+// public page of a job.
+func NewWriter(format string, w io.Writer, jobURL func(uuid.UUID) string) (Writer, error) {
+	base := encoder{w: w, enc: xml.NewEncoder(w), jobURL: jobURL}
+	switch format {
+	case FormatRSS:
+		return &rssWriter{base}, nil
+	case FormatAtom:
+		return &atomWriter{base}, nil
+	case FormatIndeed:
+		return &indeedWriter{base}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// encoder holds what the writers share: the XML encoder and helpers to open
+// and close the elements wrapping the jobs.
+type encoder struct {
+	w      io.Writer
+	enc    *xml.Encoder
+	jobURL func(uuid.UUID) string
+	open   []xml.StartElement
+}
+
+func (e *encoder) header() error {
+	_, err := io.WriteString(e.w, xml.Header)
+	return err
+}
+
+func (e *encoder) start(name string, attrs ...xml.Attr) error {
+	el := xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs}
+	e.open = append(e.open, el)
+	return e.enc.EncodeToken(el)
+}
+
+// element writes a complete element, flushing it to the underlying writer.
+func (e *encoder) element(v interface{}, name string) error {
+	if err := e.enc.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+		return err
+	}
+	return e.enc.Flush()
+}
+
+// end closes every element opened with start.
+func (e *encoder) end() error {
+	for i := len(e.open) - 1; i >= 0; i-- {
+		if err := e.enc.EncodeToken(e.open[i].End()); err != nil {
+			return err
+		}
+	}
+	e.open = nil
+	return e.enc.Flush()
+}
+
+func companyName(company *domain.User) string {
+	if company == nil {
+		return ""
+	}
+	return company.DisplayName()
+}
+
+// published returns when a job went live, falling back to its creation.
+func published(job *domain.Job) time.Time {
+	if job.PublishedAt != nil {
+		return *job.PublishedAt
+	}
+	return job.CreatedAt
+}
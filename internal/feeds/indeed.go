@@ -0,0 +1,103 @@
+package feeds
+
+import (
+	"strings"
+	"time"
+
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// indeedWriter writes the XML format read by Indeed and many other job
+// aggregators. Text fields are wrapped in CDATA as the format expects.
+type indeedWriter struct {
+	encoder
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+type indeedJob struct {
+	Title           cdata  `xml:"title"`
+	Date            cdata  `xml:"date"`
+	ReferenceNumber cdata  `xml:"referencenumber"`
+	URL             cdata  `xml:"url"`
+	Company         cdata  `xml:"company"`
+	City            cdata  `xml:"city"`
+	State           cdata  `xml:"state"`
+	Country         cdata  `xml:"country"`
+	Description     cdata  `xml:"description"`
+	Salary          *cdata `xml:"salary,omitempty"`
+	JobType         cdata  `xml:"jobtype"`
+	Experience      cdata  `xml:"experience"`
+	ExpirationDate  *cdata `xml:"expirationdate,omitempty"`
+	RemoteType      *cdata `xml:"remotetype,omitempty"`
+}
+
+func (w *indeedWriter) Begin(channel Channel) error {
+	if err := w.header(); err != nil {
+		return err
+	}
+	if err := w.start("source"); err != nil {
+		return err
+	}
+	if err := w.element(channel.Title, "publisher"); err != nil {
+		return err
+	}
+	if err := w.element(channel.Link, "publisherurl"); err != nil {
+		return err
+	}
+	if !channel.Updated.IsZero() {
+		return w.element(channel.Updated.UTC().Format(time.RFC1123), "lastBuildDate")
+	}
+	return nil
+}
+
+func (w *indeedWriter) Job(job *domain.Job, company *domain.User) error {
+	city, state, country := splitLocation(job.Location)
+	item := indeedJob{
+		Title:           cdata{job.Title},
+		Date:            cdata{published(job).UTC().Format(time.RFC1123)},
+		ReferenceNumber: cdata{job.ID.String()},
+		URL:             cdata{w.jobURL(job.ID)},
+		Company:         cdata{companyName(company)},
+		City:            cdata{city},
+		State:           cdata{state},
+		Country:         cdata{country},
+		Description:     cdata{job.Description},
+		JobType:         cdata{job.JobType},
+		Experience:      cdata{job.ExperienceLevel},
+	}
+	if job.SalaryRange != nil {
+		item.Salary = &cdata{*job.SalaryRange}
+	}
+	if job.ExpiresAt != nil {
+		item.ExpirationDate = &cdata{job.ExpiresAt.UTC().Format(time.RFC1123)}
+	}
+	if domain.IsRemoteLocation(job.Location) {
+		item.City = cdata{}
+		item.RemoteType = &cdata{"Fully remote"}
+	}
+	return w.element(item, "job")
+}
+
+func (w *indeedWriter) End() error {
+	return w.end()
+}
+
+// splitLocation reads a location written as "City", "City, Country" or
+// "City, State, Country".
+func splitLocation(location string) (city, state, country string) {
+	parts := strings.Split(location, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	switch len(parts) {
+	case 1:
+		return parts[0], "", ""
+	case 2:
+		return parts[0], "", parts[1]
+	default:
+		return parts[0], strings.Join(parts[1:len(parts)-1], ", "), parts[len(parts)-1]
+	}
+}
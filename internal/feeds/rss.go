@@ -0,0 +1,80 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+type rssWriter struct {
+	encoder
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	Description string   `xml:"description"`
+	Author      string   `xml:"dc:creator,omitempty"`
+	Categories  []string `xml:"category"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func (w *rssWriter) Begin(channel Channel) error {
+	if err := w.header(); err != nil {
+		return err
+	}
+	err := w.start("rss",
+		xml.Attr{Name: xml.Name{Local: "version"}, Value: "2.0"},
+		xml.Attr{Name: xml.Name{Local: "xmlns:atom"}, Value: "http://www.w3.org/2005/Atom"},
+		xml.Attr{Name: xml.Name{Local: "xmlns:dc"}, Value: "http://purl.org/dc/elements/1.1/"},
+	)
+	if err != nil {
+		return err
+	}
+	if err := w.start("channel"); err != nil {
+		return err
+	}
+
+	self := atomLink{Href: channel.Self, Rel: "self", Type: "application/rss+xml"}
+	for _, field := range []struct {
+		v    interface{}
+		name string
+	}{
+		{channel.Title, "title"},
+		{channel.Link, "link"},
+		{channel.Description, "description"},
+		{self, "atom:link"},
+	} {
+		if err := w.element(field.v, field.name); err != nil {
+			return err
+		}
+	}
+	if !channel.Updated.IsZero() {
+		return w.element(channel.Updated.UTC().Format(time.RFC1123Z), "lastBuildDate")
+	}
+	return nil
+}
+
+func (w *rssWriter) Job(job *domain.Job, company *domain.User) error {
+	item := rssItem{
+		Title:       job.Title,
+		Link:        w.jobURL(job.ID),
+		GUID:        rssGUID{Value: job.ID.String()},
+		Description: job.Description,
+		Author:      companyName(company),
+		Categories:  append([]string{job.JobType}, job.Skills...),
+		PubDate:     published(job).UTC().Format(time.RFC1123Z),
+	}
+	return w.element(item, "item")
+}
+
+func (w *rssWriter) End() error {
+	return w.end()
+}
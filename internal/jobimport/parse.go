@@ -0,0 +1,198 @@
+package jobimport
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+var (
+	ErrEmptyFile      = errors.New("the file has no header row")
+	ErrUnknownField   = errors.New("column mapping names an unknown job field")
+	ErrMissingColumns = errors.New("the file is missing required columns")
+)
+
+// Fields lists the job fields that can be imported, in the order they are
+// reported.
+var Fields = []string{
+	"title", "description", "location", "salary_range", "job_type", "experience_level",
+	"skills", "openings", "auto_reject_remaining", "apply_method", "apply_url",
+	"publish_at", "expires_at", "application_deadline",
+}
+
+var requiredFields = []string{"title", "description", "location", "job_type", "experience_level", "skills"}
+
+// Parse turns a table whose first row holds column headers into one import
+// row per remaining line. Values that can't be converted are reported as
+// field errors on their row rather than failing the whole table.
+func Parse(table [][]string, mapping map[string]string) ([]domain.JobImportRow, error) {
+	if len(table) == 0 {
+		return nil, ErrEmptyFile
+	}
+
+	columns, err := resolveColumns(table[0], mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]domain.JobImportRow, 0, len(table)-1)
+	for i, record := range table[1:] {
+		row := domain.JobImportRow{Line: i + 2}
+		values := make(map[string]string, len(columns))
+		for field, index := range columns {
+			if index < len(record) {
+				values[field] = strings.TrimSpace(record[index])
+			}
+		}
+
+		row.Blank = true
+		for _, value := range values {
+			if value != "" {
+				row.Blank = false
+				break
+			}
+		}
+		if !row.Blank {
+			row.Request, row.Errors = parseRequest(values)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// resolveColumns finds the column index of every importable field present
+// in the header. Headers are matched ignoring case and surrounding spaces.
+func resolveColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	known := make(map[string]bool, len(Fields))
+	for _, field := range Fields {
+		known[field] = true
+	}
+	for field := range mapping {
+		if !known[field] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	columns := make(map[string]int)
+	for _, field := range Fields {
+		name := field
+		if mapped, ok := mapping[field]; ok {
+			name = mapped
+		}
+		if i, ok := index[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		}
+	}
+
+	var missing []string
+	for _, field := range requiredFields {
+		if _, ok := columns[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingColumns, strings.Join(missing, ", "))
+	}
+	return columns, nil
+}
+
+func parseRequest(values map[string]string) (domain.CreateJobRequest, []domain.ImportFieldError) {
+	var errs []domain.ImportFieldError
+	fail := func(field, message string) {
+		errs = append(errs, domain.ImportFieldError{Field: field, Message: message})
+	}
+
+	req := domain.CreateJobRequest{
+		Title:           values["title"],
+		Description:     values["description"],
+		Location:        values["location"],
+		JobType:         values["job_type"],
+		ExperienceLevel: values["experience_level"],
+		Skills:          splitList(values["skills"]),
+		ApplyMethod:     strings.ToLower(values["apply_method"]),
+	}
+	if salary := values["salary_range"]; salary != "" {
+		req.SalaryRange = &salary
+	}
+	if applyURL := values["apply_url"]; applyURL != "" {
+		req.ApplyURL = &applyURL
+	}
+
+	if value := values["openings"]; value != "" {
+		openings, err := strconv.Atoi(value)
+		if err != nil {
+			fail("openings", "Must be a whole number")
+		}
+		req.Openings = openings
+	}
+	if value := values["auto_reject_remaining"]; value != "" {
+		switch strings.ToLower(value) {
+		case "true", "yes", "y", "1":
+			req.AutoRejectRemaining = true
+		case "false", "no", "n", "0":
+		default:
+			fail("auto_reject_remaining", "Must be true or false")
+		}
+	}
+
+	for _, date := range []struct {
+		field  string
+		target **time.Time
+	}{
+		{"publish_at", &req.PublishAt},
+		{"expires_at", &req.ExpiresAt},
+		{"application_deadline", &req.ApplicationDeadline},
+	} {
+		value := values[date.field]
+		if value == "" {
+			continue
+		}
+		t, err := parseTime(value)
+		if err != nil {
+			fail(date.field, "Must be a date (2006-01-02) or an RFC 3339 timestamp")
+			continue
+		}
+		*date.target = &t
+	}
+
+	return req, errs
+}
+
+// splitList splits a cell holding several values separated by commas,
+// semicolons or pipes.
+func splitList(value string) []string {
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || r == '|'
+	})
+	list := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			list = append(list, part)
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
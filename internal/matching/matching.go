@@ -0,0 +1,303 @@
+// Package matching scores how well job seekers and jobs fit each other.
+package matching
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/skills"
+)
+
+// Weights sets how much each factor counts towards a match. They don't have
+// to add up to 1; the engine scales the ones each kind of match uses.
+// Completeness only counts when ranking candidates and History only when
+// ranking jobs.
+type Weights struct {
+	Skills       float64
+	Experience   float64
+	Location     float64
+	Completeness float64
+	History      float64
+}
+
+func DefaultWeights() Weights {
+	return Weights{Skills: 0.5, Experience: 0.25, Location: 0.15, Completeness: 0.1, History: 0.2}
+}
+
+// notInterestedPenalty is the most a job loses, as a share of its score, for
+// being like one the seeker marked as not interested.
+const notInterestedPenalty = 0.5
+
+// Preferences is what a seeker's activity says about the jobs they want.
+type Preferences struct {
+	Applied       []domain.Job
+	NotInterested []domain.Job
+}
+
+// experienceYears is the range of years of work each experience level asks
+// for. A zero max means no upper bound.
+var experienceYears = map[string]struct{ min, max float64 }{
+	"entry":     {0, 2},
+	"junior":    {0, 3},
+	"mid":       {2, 6},
+	"senior":    {5, 0},
+	"lead":      {7, 0},
+	"executive": {10, 0},
+}
+
+// NormalizeSkill reduces a skill to a form that compares equal across
+// spellings: "Node.js", "nodejs" and "NodeJS" are the same. Aliases such as
+// "golang" are mapped to their canonical skill when skills are saved.
+func NormalizeSkill(skill string) string {
+	return skills.Key(skill)
+}
+
+// Engine scores candidates against jobs and jobs against seekers.
+type Engine struct {
+	weights Weights
+}
+
+func NewEngine(weights Weights) *Engine {
+	if weights.Skills+weights.Experience+weights.Location+weights.Completeness+weights.History <= 0 {
+		weights = DefaultWeights()
+	}
+	return &Engine{weights: weights}
+}
+
+// Score rates a candidate for a job from 0 to 100 and explains each factor.
+func (e *Engine) Score(job *domain.Job, candidate *domain.CandidateProfile) domain.Match {
+	w := normalize(e.weights.Skills, e.weights.Experience, e.weights.Location, e.weights.Completeness)
+	return total([]domain.MatchFactor{
+		factor(domain.MatchFactorSkills, w[0], skillScore(job.Skills, candidate.Skills)),
+		factor(domain.MatchFactorExperience, w[1], candidateExperience(job, candidate)),
+		factor(domain.MatchFactorLocation, w[2], locationScore(job.Location, candidate.Location)),
+		factor(domain.MatchFactorCompleteness, w[3], completenessScore(candidate.ProfileCompleteness)),
+	})
+}
+
+// ScoreJob rates a job for a seeker from 0 to 100. Besides the fit of the
+// job itself, jobs like the ones the seeker applied to rank higher and jobs
+// like the ones they turned down rank lower.
+func (e *Engine) ScoreJob(job *domain.Job, seeker *domain.CandidateProfile, prefs Preferences) domain.Match {
+	w := normalize(e.weights.Skills, e.weights.Experience, e.weights.Location, e.weights.History)
+	match := total([]domain.MatchFactor{
+		factor(domain.MatchFactorSkills, w[0], skillScore(job.Skills, seeker.Skills)),
+		factor(domain.MatchFactorExperience, w[1], candidateExperience(job, seeker)),
+		factor(domain.MatchFactorLocation, w[2], locationScore(job.Location, seeker.Location)),
+		factor(domain.MatchFactorHistory, w[3], historyScore(job, prefs.Applied)),
+	})
+
+	like, title := mostSimilar(job, prefs.NotInterested)
+	if like > 0 {
+		penalty := round(match.Score / 100 * like * notInterestedPenalty)
+		match.Factors = append(match.Factors, domain.MatchFactor{
+			Factor:       domain.MatchFactorNotInterested,
+			Score:        round(like),
+			Contribution: -penalty,
+			Explanation:  fmt.Sprintf("Similar to %q, which you marked as not interested", title),
+		})
+		match.Score = round(match.Score - penalty*100)
+	}
+	return match
+}
+
+func candidateExperience(job *domain.Job, candidate *domain.CandidateProfile) scored {
+	years := domain.YearsOfExperience(candidate.EmploymentHistory, time.Now())
+	return experienceScore(job.ExperienceLevel, years, len(candidate.EmploymentHistory) > 0)
+}
+
+func normalize(weights ...float64) []float64 {
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	out := make([]float64, len(weights))
+	for i, w := range weights {
+		if sum > 0 {
+			out[i] = w / sum
+		} else {
+			out[i] = 1 / float64(len(weights))
+		}
+	}
+	return out
+}
+
+func total(factors []domain.MatchFactor) domain.Match {
+	var sum float64
+	for _, f := range factors {
+		sum += f.Contribution
+	}
+	return domain.Match{Score: round(sum * 100), Factors: factors}
+}
+
+type scored struct {
+	score       float64
+	explanation string
+}
+
+func factor(name string, weight float64, s scored) domain.MatchFactor {
+	return domain.MatchFactor{
+		Factor:       name,
+		Score:        round(s.score),
+		Weight:       round(weight),
+		Contribution: round(s.score * weight),
+		Explanation:  s.explanation,
+	}
+}
+
+func skillScore(jobSkills, candidateSkills []string) scored {
+	if len(jobSkills) == 0 {
+		return scored{1, "The job lists no required skills"}
+	}
+	have := make(map[string]bool, len(candidateSkills))
+	for _, s := range candidateSkills {
+		have[NormalizeSkill(s)] = true
+	}
+	var matched, missing []string
+	for _, s := range jobSkills {
+		if have[NormalizeSkill(s)] {
+			matched = append(matched, s)
+		} else {
+			missing = append(missing, s)
+		}
+	}
+
+	explanation := fmt.Sprintf("Has %d of %d required skills", len(matched), len(jobSkills))
+	if len(matched) > 0 {
+		explanation += " (" + strings.Join(matched, ", ") + ")"
+	}
+	if len(missing) > 0 {
+		explanation += "; missing " + strings.Join(missing, ", ")
+	}
+	return scored{float64(len(matched)) / float64(len(jobSkills)), explanation}
+}
+
+func experienceScore(level string, years float64, known bool) scored {
+	want, ok := experienceYears[strings.ToLower(level)]
+	if !ok {
+		return scored{0.5, fmt.Sprintf("Unknown experience level %q", level)}
+	}
+	if !known {
+		return scored{0.25, "No employment history to compare with the " + level + " level"}
+	}
+
+	switch {
+	case years < want.min:
+		return scored{years / want.min,
+			fmt.Sprintf("%.1f years of experience, %s roles ask for at least %g", years, level, want.min)}
+	case want.max > 0 && years > want.max:
+		// Over-qualified candidates are a weaker fit, but not by much
+		return scored{math.Max(0.5, 1-(years-want.max)/10),
+			fmt.Sprintf("%.1f years of experience, more than the %g-%g of %s roles", years, want.min, want.max, level)}
+	default:
+		return scored{1, fmt.Sprintf("%.1f years of experience fits a %s role", years, level)}
+	}
+}
+
+func locationScore(jobLocation string, candidateLocation *string) scored {
+	if domain.IsRemoteLocation(jobLocation) {
+		return scored{1, "The job is remote"}
+	}
+	if candidateLocation == nil || strings.TrimSpace(*candidateLocation) == "" {
+		return scored{0.5, "No location on the profile"}
+	}
+
+	job, candidate := locationParts(jobLocation), locationParts(*candidateLocation)
+	switch {
+	case job[0] == candidate[0]:
+		return scored{1, "Based in " + *candidateLocation}
+	case len(job) > 1 && len(candidate) > 1 && job[len(job)-1] == candidate[len(candidate)-1]:
+		return scored{0.5, "Based in the same country, " + *candidateLocation}
+	default:
+		return scored{0, "Based in " + *candidateLocation + ", the job is in " + jobLocation}
+	}
+}
+
+// locationParts splits "City, Region, Country" into lowercase parts.
+func locationParts(location string) []string {
+	parts := strings.Split(strings.ToLower(location), ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+func historyScore(job *domain.Job, applied []domain.Job) scored {
+	if len(applied) == 0 {
+		return scored{0.5, "No past applications to compare with"}
+	}
+	like, title := mostSimilar(job, applied)
+	if like == 0 {
+		return scored{0, "Unlike the jobs you applied to"}
+	}
+	return scored{like, fmt.Sprintf("Similar to %q, which you applied to", title)}
+}
+
+// mostSimilar returns how similar the job is to the most similar of others,
+// from 0 to 1, and that job's title.
+func mostSimilar(job *domain.Job, others []domain.Job) (float64, string) {
+	var best float64
+	var title string
+	for i := range others {
+		if others[i].ID == job.ID {
+			continue
+		}
+		if s := Similarity(job, &others[i]); s > best {
+			best, title = s, others[i].Title
+		}
+	}
+	return best, title
+}
+
+// Similarity compares two jobs from 0 to 1 by their skills, the words of
+// their titles and their job type.
+func Similarity(a, b *domain.Job) float64 {
+	skills := func(j *domain.Job) map[string]bool {
+		set := make(map[string]bool, len(j.Skills))
+		for _, s := range j.Skills {
+			set[NormalizeSkill(s)] = true
+		}
+		return set
+	}
+	words := func(j *domain.Job) map[string]bool {
+		set := make(map[string]bool)
+		for _, w := range strings.FieldsFunc(strings.ToLower(j.Title), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			set[w] = true
+		}
+		return set
+	}
+
+	score := 0.5*jaccard(skills(a), skills(b)) + 0.3*jaccard(words(a), words(b))
+	if strings.EqualFold(a.JobType, b.JobType) {
+		score += 0.2
+	}
+	return score
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	var shared int
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func completenessScore(completeness float64) scored {
+	score := math.Min(math.Max(completeness/100, 0), 1)
+	return scored{score, fmt.Sprintf("Profile is %.0f%% complete", completeness)}
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,243 @@
+package matching
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []float64
+		want    []float64
+	}{
+		{"already normalized", []float64{0.5, 0.25, 0.25}, []float64{0.5, 0.25, 0.25}},
+		{"scaled up", []float64{2, 1, 1}, []float64{0.5, 0.25, 0.25}},
+		{"scaled down", []float64{0.1, 0.1}, []float64{0.5, 0.5}},
+		{"zero weight kept", []float64{3, 0, 1}, []float64{0.75, 0, 0.25}},
+		{"all zero split evenly", []float64{0, 0, 0, 0}, []float64{0.25, 0.25, 0.25, 0.25}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalize(tt.weights...)
+			var sum float64
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("normalize(%v)[%d] = %v, want %v", tt.weights, i, got[i], tt.want[i])
+				}
+				sum += got[i]
+			}
+			if math.Abs(sum-1) > 1e-9 {
+				t.Errorf("normalized weights add up to %v, want 1", sum)
+			}
+		})
+	}
+}
+
+func TestNewEngineDefaultsZeroWeights(t *testing.T) {
+	if got := NewEngine(Weights{}).weights; got != DefaultWeights() {
+		t.Errorf("weights = %+v, want defaults %+v", got, DefaultWeights())
+	}
+	custom := Weights{Skills: 1}
+	if got := NewEngine(custom).weights; got != custom {
+		t.Errorf("weights = %+v, want %+v", got, custom)
+	}
+}
+
+func TestSkillScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		job, have []string
+		want      float64
+	}{
+		{"no required skills", nil, []string{"go"}, 1},
+		{"all matched", []string{"Go", "SQL"}, []string{"sql", "go"}, 1},
+		{"none matched", []string{"Go"}, []string{"Java"}, 0},
+		{"partly matched", []string{"Go", "SQL", "Kafka", "Docker"}, []string{"go", "docker"}, 0.5},
+		{"spellings compare equal", []string{"Node.js", "Type Script"}, []string{"nodejs", "typescript"}, 1},
+		{"no candidate skills", []string{"Go"}, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := skillScore(tt.job, tt.have); got.score != tt.want {
+				t.Errorf("score = %v, want %v (%s)", got.score, tt.want, got.explanation)
+			}
+		})
+	}
+}
+
+func TestExperienceScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		level string
+		years float64
+		known bool
+		want  float64
+	}{
+		{"unknown level", "wizard", 5, true, 0.5},
+		{"no history", "mid", 0, false, 0.25},
+		{"within range", "mid", 4, true, 1},
+		{"at the minimum", "senior", 5, true, 1},
+		{"level is case-insensitive", "Senior", 8, true, 1},
+		{"under-qualified", "senior", 2.5, true, 0.5},
+		{"entry with none", "entry", 0, true, 1},
+		{"slightly over-qualified", "junior", 5, true, 0.8},
+		{"over-qualified floor", "junior", 30, true, 0.5},
+		{"no upper bound", "lead", 40, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := experienceScore(tt.level, tt.years, tt.known)
+			if math.Abs(got.score-tt.want) > 1e-9 {
+				t.Errorf("score = %v, want %v (%s)", got.score, tt.want, got.explanation)
+			}
+		})
+	}
+}
+
+func TestLocationScore(t *testing.T) {
+	loc := func(s string) *string { return &s }
+	tests := []struct {
+		name      string
+		job       string
+		candidate *string
+		want      float64
+	}{
+		{"remote job", "Remote", nil, 1},
+		{"remote with region", "remote (EU)", loc("Lisbon, Portugal"), 1},
+		{"no candidate location", "Berlin, Germany", nil, 0.5},
+		{"blank candidate location", "Berlin, Germany", loc("  "), 0.5},
+		{"same city", "Berlin, Germany", loc("berlin, germany"), 1},
+		{"same city without country", "Berlin", loc("Berlin, Germany"), 1},
+		{"same country", "Berlin, Germany", loc("Munich, Germany"), 0.5},
+		{"elsewhere", "Berlin, Germany", loc("Paris, France"), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := locationScore(tt.job, tt.candidate); got.score != tt.want {
+				t.Errorf("score = %v, want %v (%s)", got.score, tt.want, got.explanation)
+			}
+		})
+	}
+}
+
+func TestCompletenessScore(t *testing.T) {
+	tests := []struct {
+		completeness float64
+		want         float64
+	}{
+		{0, 0},
+		{40, 0.4},
+		{100, 1},
+		{150, 1},
+		{-10, 0},
+	}
+	for _, tt := range tests {
+		if got := completenessScore(tt.completeness); got.score != tt.want {
+			t.Errorf("completenessScore(%v) = %v, want %v", tt.completeness, got.score, tt.want)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	berlin := "Berlin, Germany"
+	job := &domain.Job{
+		Skills:          []string{"Go", "SQL"},
+		ExperienceLevel: "mid",
+		Location:        "Berlin, Germany",
+	}
+	candidate := &domain.CandidateProfile{
+		Skills:   []string{"go"},
+		Location: &berlin,
+		EmploymentHistory: []domain.EmploymentHistory{
+			{StartDate: time.Now().AddDate(-4, 0, 0)},
+		},
+		ProfileCompleteness: 50,
+	}
+
+	tests := []struct {
+		name    string
+		weights Weights
+		want    float64
+	}{
+		// skills 0.5, experience 1, location 1, completeness 0.5
+		{"equal weights", Weights{Skills: 1, Experience: 1, Location: 1, Completeness: 1}, 75},
+		{"skills only", Weights{Skills: 1}, 50},
+		{"history is ignored", Weights{Experience: 1, History: 100}, 100},
+		{"weights are scaled", Weights{Skills: 2, Experience: 2, Location: 2, Completeness: 2}, 75},
+		{"defaults", DefaultWeights(), 50*0.5 + 25 + 15 + 10*0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := NewEngine(tt.weights).Score(job, candidate)
+			if math.Abs(match.Score-tt.want) > 0.1 {
+				t.Errorf("score = %v, want %v", match.Score, tt.want)
+			}
+			checkFactors(t, match, domain.MatchFactorCompleteness)
+		})
+	}
+}
+
+func TestScoreJob(t *testing.T) {
+	job := &domain.Job{
+		ID:              uuid.New(),
+		Title:           "Backend Engineer",
+		JobType:         "full-time",
+		Skills:          []string{"Go", "SQL"},
+		ExperienceLevel: "mid",
+		Location:        "Remote",
+	}
+	seeker := &domain.CandidateProfile{Skills: []string{"go", "sql"}}
+	like := domain.Job{ID: uuid.New(), Title: "Backend Engineer", JobType: "full-time", Skills: []string{"Go", "SQL"}}
+	unlike := domain.Job{ID: uuid.New(), Title: "Barista", JobType: "part-time", Skills: []string{"Coffee"}}
+	engine := NewEngine(Weights{Skills: 1, Experience: 1, Location: 1, History: 1})
+
+	tests := []struct {
+		name  string
+		prefs Preferences
+		want  float64
+	}{
+		// skills 1, experience 0.25 without history, location 1, history as below
+		{"no applications", Preferences{}, (1 + 0.25 + 1 + 0.5) / 4 * 100},
+		{"applied to a similar job", Preferences{Applied: []domain.Job{like}}, (1 + 0.25 + 1 + 1) / 4 * 100},
+		{"applied to unlike jobs", Preferences{Applied: []domain.Job{unlike}}, (1 + 0.25 + 1 + 0) / 4 * 100},
+		{"the job itself doesn't count", Preferences{Applied: []domain.Job{*job}}, (1 + 0.25 + 1 + 0) / 4 * 100},
+		{"marked a similar job not interested", Preferences{NotInterested: []domain.Job{like}},
+			(1 + 0.25 + 1 + 0.5) / 4 * 100 * (1 - notInterestedPenalty)},
+		{"marked an unlike job not interested", Preferences{NotInterested: []domain.Job{unlike}}, (1 + 0.25 + 1 + 0.5) / 4 * 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := engine.ScoreJob(job, seeker, tt.prefs)
+			if math.Abs(match.Score-tt.want) > 0.1 {
+				t.Errorf("score = %v, want %v", match.Score, tt.want)
+			}
+			checkFactors(t, match, domain.MatchFactorHistory)
+		})
+	}
+}
+
+// checkFactors checks that a match has the four weighted factors, ending in
+// last, with weights adding up to 1 and the score adding up the
+// contributions, penalties included.
+func checkFactors(t *testing.T, match domain.Match, last string) {
+	t.Helper()
+	if len(match.Factors) < 4 || match.Factors[3].Factor != last {
+		t.Fatalf("factors = %+v, want four weighted ones ending in %s", match.Factors, last)
+	}
+	var weights, contributions float64
+	for _, f := range match.Factors {
+		weights += f.Weight
+		contributions += f.Contribution
+	}
+	if math.Abs(weights-1) > 0.01 {
+		t.Errorf("weights add up to %v, want 1", weights)
+	}
+	if math.Abs(contributions*100-match.Score) > 0.5 {
+		t.Errorf("contributions add up to %v, score is %v", contributions*100, match.Score)
+	}
+}
@@ -2,30 +2,103 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/zahidhasann88/job-board-api/internal/domain"
 )
 
+// ErrVersionConflict is returned when a write is made against a version of a
+// record that is no longer current.
+var ErrVersionConflict = errors.New("record was modified by another request")
+
+// JobRepository writes every change to a job's content or status together
+// with a revision snapshotting the result.
 type JobRepository interface {
 	Create(ctx context.Context, job *domain.Job) error
-	Update(ctx context.Context, job *domain.Job) error
+	Update(ctx context.Context, job *domain.Job, changeType string, changedBy *uuid.UUID) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error)
 	List(ctx context.Context, filter domain.JobFilter) ([]domain.Job, int, error)
-	ChangeJobStatus(ctx context.Context, id uuid.UUID, status string) error
+	Stream(ctx context.Context, filter domain.JobFilter, fn func(*domain.Job) error) error
+	LastUpdated(ctx context.Context, filter domain.JobFilter) (time.Time, int, error)
+	ChangeJobStatus(ctx context.Context, id uuid.UUID, status string, changedBy *uuid.UUID) error
+	ChangeJobStatuses(ctx context.Context, changes []domain.JobStatusChange, changedBy *uuid.UUID) error
+	ReviewJob(ctx context.Context, id uuid.UUID, status string, reviewerID uuid.UUID, note *string, fraud bool) error
 	GetJobAnalytics(ctx context.Context, companyID uuid.UUID) (*domain.JobAnalytics, error)
 	GetApplicationInsights(ctx context.Context, jobID uuid.UUID) (*domain.JobApplicationInsights, error)
+	ListApplicantBackgrounds(ctx context.Context, jobID uuid.UUID) ([]domain.ApplicantBackground, error)
 	BulkCreate(ctx context.Context, jobs []domain.Job) error
-	GetRecommendedCandidates(ctx context.Context, jobID uuid.UUID) ([]domain.RecommendedCandidate, error)
+	ListDuplicateCandidates(ctx context.Context, companyID *uuid.UUID) ([]domain.Job, error)
+
+	// Scheduling
+	ListDueForPublish(ctx context.Context, now time.Time) ([]domain.Job, error)
+	ListDueForExpiry(ctx context.Context, now time.Time) ([]domain.Job, error)
+	ListExpiringBefore(ctx context.Context, before time.Time) ([]domain.Job, error)
+	MarkExpiryReminderSent(ctx context.Context, id uuid.UUID) error
+	Renew(ctx context.Context, id uuid.UUID, expiresAt time.Time, status string, changedBy *uuid.UUID) error
+	Unschedule(ctx context.Context, id uuid.UUID) error
+
+	// Seeker preferences
+	ListAppliedJobs(ctx context.Context, userID uuid.UUID) ([]domain.Job, error)
+	MarkNotInterested(ctx context.Context, userID, jobID uuid.UUID) error
+	ClearNotInterested(ctx context.Context, userID, jobID uuid.UUID) error
+	ListNotInterested(ctx context.Context, userID uuid.UUID) ([]domain.Job, error)
+
+	// Trash
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	ListDeleted(ctx context.Context, companyID uuid.UUID) ([]domain.Job, error)
+	Restore(ctx context.Context, id uuid.UUID, changedBy *uuid.UUID) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
+}
+
+type JobRevisionRepository interface {
+	List(ctx context.Context, jobID uuid.UUID) ([]domain.JobRevision, error)
+	Get(ctx context.Context, jobID uuid.UUID, revision int) (*domain.JobRevision, error)
+}
+
+type ApplyClickRepository interface {
+	Create(ctx context.Context, click *domain.ApplyClick) error
+}
+
+type EngagementRepository interface {
+	InsertBatch(ctx context.Context, events []domain.EngagementEvent) error
+	DailyCounts(ctx context.Context, jobID uuid.UUID, from, to time.Time) ([]domain.EngagementCount, error)
+}
+
+type JobTemplateRepository interface {
+	Create(ctx context.Context, template *domain.JobTemplate) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.JobTemplate, error)
+	ListByCompany(ctx context.Context, companyID uuid.UUID) ([]domain.JobTemplate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type TaskRepository interface {
+	Create(ctx context.Context, task *domain.Task) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Task, error)
+	ListQueued(ctx context.Context) ([]uuid.UUID, error)
+	Start(ctx context.Context, id uuid.UUID) (bool, error)
+	UpdateProgress(ctx context.Context, id uuid.UUID, total, processed, failed int, errs []domain.TaskItemError) error
+	Finish(ctx context.Context, id uuid.UUID, status string, result []byte, taskErr *string) error
+	RequestCancel(ctx context.Context, id uuid.UUID) error
+	FailInterrupted(ctx context.Context) (int, error)
 }
 
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetProfile(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	SetVerified(ctx context.Context, id uuid.UUID, verified bool) error
+
+	// Trash
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	GetDeletedByEmail(ctx context.Context, email string) (*domain.User, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
 
 	// Profile-specific methods
 	UpdateProfileDetails(ctx context.Context, userID uuid.UUID, updates map[string]interface{}) error
@@ -36,9 +109,25 @@ type UserRepository interface {
 	UpdateEducationHistory(ctx context.Context, userID uuid.UUID, history []domain.EducationHistory) error
 	UpdateCertifications(ctx context.Context, userID uuid.UUID, certifications []domain.Certification) error
 
+	// Matching
+	ListCandidateProfiles(ctx context.Context, notAppliedTo uuid.UUID) ([]domain.CandidateProfile, error)
+	GetCandidateProfile(ctx context.Context, userID uuid.UUID) (*domain.CandidateProfile, error)
+
+	// Candidate search
+	SearchCandidates(ctx context.Context, companyID uuid.UUID, search domain.CandidateSearch, page, pageSize int) ([]domain.CandidateSummary, int, error)
+
+	// Privacy
+	GetPrivacySettings(ctx context.Context, userID uuid.UUID) (*domain.PrivacySettings, error)
+	UpdatePrivacySettings(ctx context.Context, userID uuid.UUID, settings *domain.PrivacySettings) error
+	BlockCompany(ctx context.Context, userID, companyID uuid.UUID) error
+	UnblockCompany(ctx context.Context, userID, companyID uuid.UUID) error
+	VisibleToCompany(ctx context.Context, userID, companyID uuid.UUID) (bool, error)
+
 	// Analytics
 	GetUserAnalytics(ctx context.Context, userID uuid.UUID) (*domain.UserAnalytics, error)
-	IncrementProfileView(ctx context.Context, userID uuid.UUID) error
+	IncrementProfileView(ctx context.Context, view *domain.ProfileView) error
+	ProfileViewsByDay(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.ProfileViewDay, error)
+	ProfileViewers(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.ProfileViewer, error)
 }
 
 type ApplicationRepository interface {
@@ -51,3 +140,9 @@ type ApplicationRepository interface {
 	// GetApplicationsByJob(ctx context.Context, jobID uuid.UUID) ([]domain.Application, error)
 	// GetApplicationsByUser(ctx context.Context, userID uuid.UUID) ([]domain.Application, error)
 }
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *domain.Notification) error
+	List(ctx context.Context, filter domain.NotificationFilter) ([]domain.Notification, int, error)
+	MarkRead(ctx context.Context, id, userID uuid.UUID) error
+}
@@ -8,6 +8,22 @@ import (
 	"github.com/zahidhasann88/job-board-api/internal/domain"
 )
 
+const applicationColumns = `id, job_id, applicant_id, cover_letter, resume_url, status, source, created_at, updated_at`
+
+func scanApplication(row rowScanner, app *domain.Application) error {
+	return row.Scan(
+		&app.ID,
+		&app.JobID,
+		&app.ApplicantID,
+		&app.CoverLetter,
+		&app.ResumeURL,
+		&app.Status,
+		&app.Source,
+		&app.CreatedAt,
+		&app.UpdatedAt,
+	)
+}
+
 type ApplicationRepository struct {
 	db *sql.DB
 }
@@ -16,14 +32,21 @@ func NewApplicationRepository(db *sql.DB) *ApplicationRepository {
 	return &ApplicationRepository{db: db}
 }
 
+// Create stores the application and starts its status history.
 func (r *ApplicationRepository) Create(ctx context.Context, application *domain.Application) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
         INSERT INTO applications (
-            id, job_id, applicant_id, cover_letter, resume_url, status
-        ) VALUES ($1, $2, $3, $4, $5, $6)
+            id, job_id, applicant_id, cover_letter, resume_url, status, source
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7)
         RETURNING created_at, updated_at`
 
-	return r.db.QueryRowContext(
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		application.ID,
@@ -32,14 +55,26 @@ func (r *ApplicationRepository) Create(ctx context.Context, application *domain.
 		application.CoverLetter,
 		application.ResumeURL,
 		application.Status,
+		application.Source,
 	).Scan(&application.CreatedAt, &application.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := insertStatusChange(ctx, tx, application.ID, nil, application.Status, &application.ApplicantID); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (r *ApplicationRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Application, error) {
+	// Applications to deleted jobs stay hidden until the job is restored
 	query := `
-        SELECT id, job_id, applicant_id, cover_letter, resume_url, status, created_at, updated_at
-        FROM applications
-        WHERE applicant_id = $1
+        SELECT a.id, a.job_id, a.applicant_id, a.cover_letter, a.resume_url, a.status, a.source,
+               a.created_at, a.updated_at
+        FROM applications a
+        JOIN jobs j ON j.id = a.job_id
+        WHERE a.applicant_id = $1 AND j.deleted_at IS NULL
     `
 
 	rows, err := r.db.QueryContext(ctx, query, userID)
@@ -51,6 +86,115 @@ func (r *ApplicationRepository) ListByUser(ctx context.Context, userID uuid.UUID
 	var applications []domain.Application
 	for rows.Next() {
 		var app domain.Application
+		if err := scanApplication(rows, &app); err != nil {
+			return nil, err
+		}
+		applications = append(applications, app)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+func (r *ApplicationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Application, error) {
+	app := &domain.Application{}
+	query := `
+        SELECT ` + applicationColumns + `
+        FROM applications
+        WHERE id = $1`
+
+	err := scanApplication(r.db.QueryRowContext(ctx, query, id), app)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// UpdateStatus changes an application's status and records the change in its
+// history.
+func (r *ApplicationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string, changedBy *uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var from string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM applications WHERE id = $1 FOR UPDATE`, id).Scan(&from)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if from == status {
+		return nil
+	}
+
+	query := `
+        UPDATE applications
+        SET status = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2`
+
+	if _, err := tx.ExecContext(ctx, query, status, id); err != nil {
+		return err
+	}
+	if err := insertStatusChange(ctx, tx, id, &from, status, changedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *ApplicationRepository) CountByStatus(ctx context.Context, jobID uuid.UUID, status string) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM applications
+        WHERE job_id = $1 AND status = $2`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, jobID, status).Scan(&count)
+	return count, err
+}
+
+// RejectOpen rejects every application for the job that hasn't been accepted
+// or rejected yet and returns the applications it changed.
+func (r *ApplicationRepository) RejectOpen(ctx context.Context, jobID uuid.UUID) ([]domain.Application, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE applications a
+        SET status = 'rejected', updated_at = CURRENT_TIMESTAMP
+        FROM (
+            SELECT id, status FROM applications
+            WHERE job_id = $1 AND status NOT IN ('accepted', 'rejected')
+            FOR UPDATE
+        ) prior
+        WHERE a.id = prior.id
+        RETURNING a.id, a.job_id, a.applicant_id, a.cover_letter, a.resume_url, a.status, a.source,
+                  a.created_at, a.updated_at, prior.status`
+
+	rows, err := tx.QueryContext(ctx, query, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var applications []domain.Application
+	var previous []string
+	for rows.Next() {
+		var app domain.Application
+		var from string
 		if err := rows.Scan(
 			&app.ID,
 			&app.JobID,
@@ -58,17 +202,34 @@ func (r *ApplicationRepository) ListByUser(ctx context.Context, userID uuid.UUID
 			&app.CoverLetter,
 			&app.ResumeURL,
 			&app.Status,
+			&app.Source,
 			&app.CreatedAt,
 			&app.UpdatedAt,
+			&from,
 		); err != nil {
 			return nil, err
 		}
 		applications = append(applications, app)
+		previous = append(previous, from)
 	}
-
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	rows.Close()
 
-	return applications, nil
+	for i, app := range applications {
+		if err := insertStatusChange(ctx, tx, app.ID, &previous[i], app.Status, nil); err != nil {
+			return nil, err
+		}
+	}
+	return applications, tx.Commit()
+}
+
+func insertStatusChange(ctx context.Context, tx *sql.Tx, applicationID uuid.UUID, from *string, to string, changedBy *uuid.UUID) error {
+	query := `
+        INSERT INTO application_status_history (id, application_id, from_status, to_status, changed_by)
+        VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := tx.ExecContext(ctx, query, uuid.New(), applicationID, from, to, changedBy)
+	return err
 }
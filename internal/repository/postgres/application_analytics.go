@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// ListActivity returns the company's applications that were submitted or
+// hired between from and to, with the milestones of their status history.
+func (r *ApplicationRepository) ListActivity(ctx context.Context, companyID uuid.UUID, from, to time.Time) ([]domain.ApplicationActivity, error) {
+	query := `
+        WITH activity AS (
+            SELECT a.id, a.job_id, j.title, j.location, j.job_type, a.source, a.created_at, a.status,
+                   (SELECT MIN(h.changed_at) FROM application_status_history h
+                    WHERE h.application_id = a.id AND h.to_status <> 'pending') AS first_review_at,
+                   (SELECT MIN(h.changed_at) FROM application_status_history h
+                    WHERE h.application_id = a.id AND h.to_status = 'accepted') AS hired_at,
+                   ARRAY(SELECT DISTINCT h.to_status FROM application_status_history h
+                         WHERE h.application_id = a.id) AS statuses
+            FROM applications a
+            JOIN jobs j ON j.id = a.job_id
+            WHERE j.company_id = $1 AND j.deleted_at IS NULL
+        )
+        SELECT * FROM activity
+        WHERE (created_at >= $2 AND created_at < $3) OR (hired_at >= $2 AND hired_at < $3)
+        ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, companyID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var activity []domain.ApplicationActivity
+	for rows.Next() {
+		var a domain.ApplicationActivity
+		var status string
+		if err := rows.Scan(
+			&a.ApplicationID,
+			&a.JobID,
+			&a.JobTitle,
+			&a.Location,
+			&a.JobType,
+			&a.Source,
+			&a.CreatedAt,
+			&status,
+			&a.FirstReviewAt,
+			&a.HiredAt,
+			pq.Array(&a.Statuses),
+		); err != nil {
+			return nil, err
+		}
+		// Applications from before the history was kept only have their
+		// current status
+		a.Statuses = append(a.Statuses, status)
+		activity = append(activity, a)
+	}
+	return activity, rows.Err()
+}
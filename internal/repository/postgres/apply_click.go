@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+type ApplyClickRepository struct {
+	db *sql.DB
+}
+
+func NewApplyClickRepository(db *sql.DB) *ApplyClickRepository {
+	return &ApplyClickRepository{db: db}
+}
+
+func (r *ApplyClickRepository) Create(ctx context.Context, click *domain.ApplyClick) error {
+	query := `
+        INSERT INTO job_apply_clicks (id, job_id, referrer, visitor_hash)
+        VALUES ($1, $2, $3, $4)
+        RETURNING created_at`
+
+	return r.db.QueryRowContext(ctx, query, click.ID, click.JobID, click.Referrer, click.VisitorHash).
+		Scan(&click.CreatedAt)
+}
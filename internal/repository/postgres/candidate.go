@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// SearchCandidates returns a page of the job seekers matching the search
+// whose profiles the company may see, most relevant first, with their
+// employment history and certifications, and how many match in all.
+// Years of experience add up the employment history with overlapping jobs
+// counted once, as domain.YearsOfExperience does.
+func (r *UserRepository) SearchCandidates(ctx context.Context, companyID uuid.UUID, search domain.CandidateSearch, page, pageSize int) ([]domain.CandidateSummary, int, error) {
+	conditions := []string{"u.role = 'job_seeker'", "u.deleted_at IS NULL", visibleToCompany("$1")}
+	args := []interface{}{companyID}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	relevance := "0"
+	if search.Query != "" {
+		add("d.document @@ websearch_to_tsquery('english', $%d)", search.Query)
+		relevance = fmt.Sprintf("ts_rank(d.document, websearch_to_tsquery('english', $%d))", len(args))
+	}
+	for _, skill := range search.Skills {
+		add("$%d = ANY(u.skills)", skill)
+	}
+	if search.Location != nil {
+		add(`u.location ILIKE '%%' || $%d || '%%' ESCAPE '\'`, escapeLike(*search.Location))
+	}
+	for _, name := range search.Certifications {
+		add(`EXISTS (SELECT 1 FROM user_certifications c
+                     WHERE c.user_id = u.id AND c.name ILIKE '%%' || $%d || '%%' ESCAPE '\')`, escapeLike(name))
+	}
+	if search.OpenToWork != nil {
+		add("u.open_to_work = $%d", *search.OpenToWork)
+	}
+	if search.MinYears != nil {
+		add("x.years >= $%d", *search.MinYears)
+	}
+	if search.MaxYears != nil {
+		add("x.years <= $%d", *search.MaxYears)
+	}
+
+	from := `
+        FROM users u, LATERAL (
+            SELECT to_tsvector('english', concat_ws(' ', u.bio, u.experience,
+                (SELECT string_agg(concat_ws(' ', e.title, e.company, e.description), ' ')
+                 FROM user_employment_history e WHERE e.user_id = u.id))) AS document
+        ) d, LATERAL (
+            SELECT COALESCE(SUM(upper(span) - lower(span)), 0) / 365.25 AS years
+            FROM unnest((
+                SELECT range_agg(daterange(e.start_date::date, COALESCE(e.end_date::date, CURRENT_DATE)))
+                FROM user_employment_history e
+                WHERE e.user_id = u.id AND COALESCE(e.end_date::date, CURRENT_DATE) > e.start_date::date
+            )) AS span
+        ) x
+        WHERE ` + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT u.id, u.full_name, u.bio, u.location, COALESCE(u.skills, '{}'), u.open_to_work,
+               x.years, %s AS relevance%s
+        ORDER BY relevance DESC, u.updated_at DESC
+        LIMIT $%d OFFSET $%d`, relevance, from, len(args)+1, len(args)+2)
+	args = append(args, pageSize, (page-1)*pageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var candidates []domain.CandidateSummary
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var c domain.CandidateSummary
+		if err := rows.Scan(&c.UserID, &c.FullName, &c.Bio, &c.Location, pq.Array(&c.Skills), &c.OpenToWork, &c.YearsOfExperience, &c.Relevance); err != nil {
+			return nil, 0, err
+		}
+		c.Certifications = []string{}
+		index[c.UserID] = len(candidates)
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if len(candidates) == 0 {
+		return candidates, total, nil
+	}
+
+	ids := make([]uuid.UUID, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.UserID
+	}
+
+	rows, err = r.db.QueryContext(ctx, `
+        SELECT user_id, company, title, start_date, end_date
+        FROM user_employment_history
+        WHERE user_id = ANY($1)
+        ORDER BY start_date DESC`, pq.Array(ids))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userID uuid.UUID
+		var emp domain.EmploymentHistory
+		if err := rows.Scan(&userID, &emp.Company, &emp.Title, &emp.StartDate, &emp.EndDate); err != nil {
+			return nil, 0, err
+		}
+		c := &candidates[index[userID]]
+		c.EmploymentHistory = append(c.EmploymentHistory, emp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err = r.db.QueryContext(ctx, `
+        SELECT user_id, name
+        FROM user_certifications
+        WHERE user_id = ANY($1)
+        ORDER BY issue_date DESC`, pq.Array(ids))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userID uuid.UUID
+		var name string
+		if err := rows.Scan(&userID, &name); err != nil {
+			return nil, 0, err
+		}
+		c := &candidates[index[userID]]
+		c.Certifications = append(c.Certifications, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return candidates, total, nil
+}
+
+// escapeLike escapes the wildcards in s so that LIKE and ILIKE patterns
+// built from it, with ESCAPE '\', match it literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
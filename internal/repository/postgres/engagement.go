@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+type EngagementRepository struct {
+	db *sql.DB
+}
+
+func NewEngagementRepository(db *sql.DB) *EngagementRepository {
+	return &EngagementRepository{db: db}
+}
+
+// InsertBatch stores events in one transaction. Events already recorded for
+// the visitor that day are ignored.
+func (r *EngagementRepository) InsertBatch(ctx context.Context, events []domain.EngagementEvent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+        INSERT INTO job_engagement_events (job_id, day, event, visitor_hash)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, e := range events {
+		if _, err := stmt.ExecContext(ctx, e.JobID, e.Day, e.Event, e.VisitorHash); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DailyCounts returns the distinct visitors per day and event for a job
+// between from and to, both inclusive.
+func (r *EngagementRepository) DailyCounts(ctx context.Context, jobID uuid.UUID, from, to time.Time) ([]domain.EngagementCount, error) {
+	query := `
+        SELECT day, event, COUNT(*)
+        FROM job_engagement_events
+        WHERE job_id = $1 AND day BETWEEN $2 AND $3
+        GROUP BY day, event
+        ORDER BY day`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []domain.EngagementCount
+	for rows.Next() {
+		var c domain.EngagementCount
+		if err := rows.Scan(&c.Day, &c.Event, &c.Visitors); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+	"github.com/zahidhasann88/job-board-api/internal/screening"
 )
 
 type JobRepository struct {
@@ -18,15 +21,79 @@ func NewJobRepository(db *sql.DB) *JobRepository {
 	return &JobRepository{db: db}
 }
 
+const jobColumns = `id, title, description, company_id, location, salary_range,
+               job_type, experience_level, skills, status, openings,
+               auto_reject_remaining, apply_method, apply_url, moderation_note,
+               reviewed_by, reviewed_at, risk_score, risk_reasons,
+               publish_at, published_at, expires_at, application_deadline,
+               expiry_reminder_sent_at, version, created_at, updated_at, deleted_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanJob(row rowScanner, job *domain.Job) error {
+	return row.Scan(
+		&job.ID,
+		&job.Title,
+		&job.Description,
+		&job.CompanyID,
+		&job.Location,
+		&job.SalaryRange,
+		&job.JobType,
+		&job.ExperienceLevel,
+		pq.Array(&job.Skills),
+		&job.Status,
+		&job.Openings,
+		&job.AutoRejectRemaining,
+		&job.ApplyMethod,
+		&job.ApplyURL,
+		&job.ModerationNote,
+		&job.ReviewedBy,
+		&job.ReviewedAt,
+		&job.RiskScore,
+		pq.Array(&job.RiskReasons),
+		&job.PublishAt,
+		&job.PublishedAt,
+		&job.ExpiresAt,
+		&job.ApplicationDeadline,
+		&job.ExpiryReminderSentAt,
+		&job.Version,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.DeletedAt,
+	)
+}
+
+// publishedAt returns when a job being saved with the given status was first
+// published, stamping the current time the first time it goes live.
+func publishedAt(job *domain.Job) *time.Time {
+	if job.PublishedAt == nil && job.Status == domain.JobStatusActive {
+		now := time.Now()
+		job.PublishedAt = &now
+	}
+	return job.PublishedAt
+}
+
+// Create inserts a job and records it as the first revision, changed by its
+// company.
 func (r *JobRepository) Create(ctx context.Context, job *domain.Job) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
         INSERT INTO jobs (
             id, title, description, company_id, location, salary_range,
-            job_type, experience_level, skills, status
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-        RETURNING created_at, updated_at`
+            job_type, experience_level, skills, status, risk_score, risk_reasons,
+            publish_at, published_at, expires_at, application_deadline,
+            openings, auto_reject_remaining, apply_method, apply_url
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+        RETURNING version, created_at, updated_at`
 
-	return r.db.QueryRowContext(
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		job.ID,
@@ -39,19 +106,50 @@ func (r *JobRepository) Create(ctx context.Context, job *domain.Job) error {
 		job.ExperienceLevel,
 		pq.Array(job.Skills),
 		job.Status,
-	).Scan(&job.CreatedAt, &job.UpdatedAt)
+		job.RiskScore,
+		pq.Array(job.RiskReasons),
+		job.PublishAt,
+		publishedAt(job),
+		job.ExpiresAt,
+		job.ApplicationDeadline,
+		job.Openings,
+		job.AutoRejectRemaining,
+		job.ApplyMethod,
+		job.ApplyURL,
+	).Scan(&job.Version, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if err := recordRevision(ctx, tx, job.ID, domain.RevisionCreated, &job.CompanyID); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (r *JobRepository) Update(ctx context.Context, job *domain.Job) error {
+// Update saves a job's editable fields and records the change as a revision
+// in the same transaction.
+func (r *JobRepository) Update(ctx context.Context, job *domain.Job, changeType string, changedBy *uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
         UPDATE jobs 
         SET title = $1, description = $2, location = $3, salary_range = $4,
             job_type = $5, experience_level = $6, skills = $7, status = $8,
-            updated_at = CURRENT_TIMESTAMP
-        WHERE id = $9
-        RETURNING updated_at`
-
-	return r.db.QueryRowContext(
+            risk_score = $9, risk_reasons = $10, publish_at = $11,
+            published_at = COALESCE(published_at, $12), expires_at = $13,
+            expiry_reminder_sent_at = CASE WHEN expires_at IS DISTINCT FROM $13
+                THEN NULL ELSE expiry_reminder_sent_at END,
+            application_deadline = $14, openings = $15, auto_reject_remaining = $16,
+            apply_method = $17, apply_url = $18, reviewed_at = $21,
+            version = version + 1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $19 AND version = $20 AND deleted_at IS NULL
+        RETURNING version, updated_at`
+
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		job.Title,
@@ -62,32 +160,40 @@ func (r *JobRepository) Update(ctx context.Context, job *domain.Job) error {
 		job.ExperienceLevel,
 		pq.Array(job.Skills),
 		job.Status,
+		job.RiskScore,
+		pq.Array(job.RiskReasons),
+		job.PublishAt,
+		publishedAt(job),
+		job.ExpiresAt,
+		job.ApplicationDeadline,
+		job.Openings,
+		job.AutoRejectRemaining,
+		job.ApplyMethod,
+		job.ApplyURL,
 		job.ID,
-	).Scan(&job.UpdatedAt)
+		job.Version,
+		job.ReviewedAt,
+	).Scan(&job.Version, &job.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return repository.ErrVersionConflict
+	}
+	if err != nil {
+		return err
+	}
+	if err := recordRevision(ctx, tx, job.ID, changeType, changedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (r *JobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
 	job := &domain.Job{}
 	query := `
-        SELECT id, title, description, company_id, location, salary_range,
-               job_type, experience_level, skills, status, created_at, updated_at
+        SELECT ` + jobColumns + `
         FROM jobs
-        WHERE id = $1`
+        WHERE id = $1 AND deleted_at IS NULL`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&job.ID,
-		&job.Title,
-		&job.Description,
-		&job.CompanyID,
-		&job.Location,
-		&job.SalaryRange,
-		&job.JobType,
-		&job.ExperienceLevel,
-		pq.Array(&job.Skills),
-		&job.Status,
-		&job.CreatedAt,
-		&job.UpdatedAt,
-	)
+	err := scanJob(r.db.QueryRowContext(ctx, query, id), job)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -99,41 +205,21 @@ func (r *JobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job,
 }
 
 func (r *JobRepository) List(ctx context.Context, filter domain.JobFilter) ([]domain.Job, int, error) {
-	// Base query
+	where, args := jobFilterWhere(filter)
+	paramCount := len(args) + 1
+
 	query := `
-        SELECT id, title, description, company_id, location, salary_range,
-               job_type, experience_level, skills, status, created_at, updated_at
+        SELECT ` + jobColumns + `
         FROM jobs
-        WHERE 1=1`
+        WHERE ` + where
 
-	countQuery := "SELECT COUNT(*) FROM jobs WHERE 1=1"
-	args := []interface{}{}
-	paramCount := 1
+	countQuery := "SELECT COUNT(*) FROM jobs WHERE " + where
 
-	// Add filters
-	if filter.Location != nil {
-		query += fmt.Sprintf(" AND location = $%d", paramCount)
-		countQuery += fmt.Sprintf(" AND location = $%d", paramCount)
-		args = append(args, *filter.Location)
-		paramCount++
-	}
-	if filter.JobType != nil {
-		query += fmt.Sprintf(" AND job_type = $%d", paramCount)
-		countQuery += fmt.Sprintf(" AND job_type = $%d", paramCount)
-		args = append(args, *filter.JobType)
-		paramCount++
-	}
-	if filter.ExperienceLevel != nil {
-		query += fmt.Sprintf(" AND experience_level = $%d", paramCount)
-		countQuery += fmt.Sprintf(" AND experience_level = $%d", paramCount)
-		args = append(args, *filter.ExperienceLevel)
-		paramCount++
-	}
-	if len(filter.Skills) > 0 {
-		query += fmt.Sprintf(" AND skills && $%d", paramCount)
-		countQuery += fmt.Sprintf(" AND skills && $%d", paramCount)
-		args = append(args, pq.Array(filter.Skills))
-		paramCount++
+	// Add ordering
+	if filter.OldestFirst {
+		query += " ORDER BY created_at ASC"
+	} else {
+		query += " ORDER BY created_at DESC"
 	}
 
 	// Add pagination
@@ -159,21 +245,7 @@ func (r *JobRepository) List(ctx context.Context, filter domain.JobFilter) ([]do
 	var jobs []domain.Job
 	for rows.Next() {
 		var job domain.Job
-		err := rows.Scan(
-			&job.ID,
-			&job.Title,
-			&job.Description,
-			&job.CompanyID,
-			&job.Location,
-			&job.SalaryRange,
-			&job.JobType,
-			&job.ExperienceLevel,
-			pq.Array(&job.Skills),
-			&job.Status,
-			&job.CreatedAt,
-			&job.UpdatedAt,
-		)
-		if err != nil {
+		if err := scanJob(rows, &job); err != nil {
 			return nil, 0, err
 		}
 		jobs = append(jobs, job)
@@ -182,30 +254,371 @@ func (r *JobRepository) List(ctx context.Context, filter domain.JobFilter) ([]do
 	return jobs, total, nil
 }
 
-func (r *JobRepository) ChangeJobStatus(ctx context.Context, id uuid.UUID, status string) error {
+// jobFilterWhere returns the WHERE clause selecting the live jobs that match
+// filter, with its arguments numbered from $1.
+func jobFilterWhere(filter domain.JobFilter) (string, []interface{}) {
+	where := "deleted_at IS NULL"
+	args := []interface{}{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		where += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if filter.Location != nil {
+		add("location = $%d", *filter.Location)
+	}
+	if filter.JobType != nil {
+		add("job_type = $%d", *filter.JobType)
+	}
+	if filter.ExperienceLevel != nil {
+		add("experience_level = $%d", *filter.ExperienceLevel)
+	}
+	if len(filter.Skills) > 0 {
+		add("skills && $%d", pq.Array(filter.Skills))
+	}
+	if filter.CompanyID != nil {
+		add("company_id = $%d", *filter.CompanyID)
+	}
+	if filter.Status != nil {
+		add("status = $%d", *filter.Status)
+	}
+	return where, args
+}
+
+// Stream calls fn for each live job matching filter, newest first, without
+// loading them all into memory. Paging fields of the filter are ignored.
+func (r *JobRepository) Stream(ctx context.Context, filter domain.JobFilter, fn func(*domain.Job) error) error {
+	where, args := jobFilterWhere(filter)
+	query := `
+        SELECT ` + jobColumns + `
+        FROM jobs
+        WHERE ` + where + `
+        ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var job domain.Job
+		if err := scanJob(rows, &job); err != nil {
+			return err
+		}
+		if err := fn(&job); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// LastUpdated returns the newest updated_at among the live jobs matching
+// filter and how many there are. The time is zero when nothing matches.
+func (r *JobRepository) LastUpdated(ctx context.Context, filter domain.JobFilter) (time.Time, int, error) {
+	where, args := jobFilterWhere(filter)
+
+	var latest sql.NullTime
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT MAX(updated_at), COUNT(*) FROM jobs WHERE "+where, args...).
+		Scan(&latest, &count)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return latest.Time, count, nil
+}
+
+func (r *JobRepository) ChangeJobStatus(ctx context.Context, id uuid.UUID, status string, changedBy *uuid.UUID) error {
 	query := `
         UPDATE jobs 
-        SET status = $1, updated_at = CURRENT_TIMESTAMP
+        SET status = $1::varchar,
+            published_at = CASE WHEN $1::varchar = 'active'
+                THEN COALESCE(published_at, CURRENT_TIMESTAMP) ELSE published_at END,
+            version = version + 1, updated_at = CURRENT_TIMESTAMP
         WHERE id = $2`
 
-	_, err := r.db.ExecContext(ctx, query, status, id)
-	return err
+	return r.writeWithRevision(ctx, id, domain.RevisionStatusChanged, changedBy, query, status, id)
 }
 
-func (r *JobRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	// Delete associated applications first (assuming you have a foreign key constraint)
-	appQuery := `DELETE FROM applications WHERE job_id = $1`
-	_, err := r.db.ExecContext(ctx, appQuery, id)
+// ChangeJobStatuses applies several status changes in one transaction. Each
+// change only applies to the version of the job it was decided on; if any
+// job has changed since, nothing is written and ErrVersionConflict is
+// returned. Each change is recorded as a revision.
+func (r *JobRepository) ChangeJobStatuses(ctx context.Context, changes []domain.JobStatusChange, changedBy *uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+        UPDATE jobs
+        SET status = $1::varchar,
+            published_at = CASE WHEN $1::varchar = 'active'
+                THEN COALESCE(published_at, CURRENT_TIMESTAMP) ELSE published_at END,
+            version = version + 1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2 AND version = $3 AND deleted_at IS NULL`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, change := range changes {
+		result, err := stmt.ExecContext(ctx, change.Status, change.JobID, change.Version)
+		if err != nil {
+			return err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return repository.ErrVersionConflict
+		}
+		if err := recordRevision(ctx, tx, change.JobID, domain.RevisionStatusChanged, changedBy); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ReviewJob records a moderator's decision. reviewed_at marks the job as
+// approved, so a rejection clears it and the job has to be reviewed again
+// before it can go live. fraud marks a rejection as fraud, which makes the
+// job a known-bad posting for fraud screening until it is approved.
+func (r *JobRepository) ReviewJob(ctx context.Context, id uuid.UUID, status string, reviewerID uuid.UUID, note *string, fraud bool) error {
+	query := `
+        UPDATE jobs
+        SET status = $1::varchar, moderation_note = $2, reviewed_by = $3,
+            reviewed_at = CASE WHEN $1::varchar = 'rejected' THEN NULL ELSE CURRENT_TIMESTAMP END,
+            rejected_as_fraud = $5,
+            published_at = CASE WHEN $1::varchar = 'active'
+                THEN COALESCE(published_at, CURRENT_TIMESTAMP) ELSE published_at END,
+            version = version + 1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $4`
+
+	return r.writeWithRevision(ctx, id, domain.RevisionReviewed, &reviewerID, query, status, note, reviewerID, id, fraud)
+}
+
+// ListDueForPublish returns scheduled drafts whose publish time has passed
+// and that have never been published.
+func (r *JobRepository) ListDueForPublish(ctx context.Context, now time.Time) ([]domain.Job, error) {
+	return r.listWhere(ctx, `status = 'draft' AND publish_at <= $1 AND published_at IS NULL`, now)
+}
+
+// ListDueForExpiry returns active jobs whose expiry time has passed.
+func (r *JobRepository) ListDueForExpiry(ctx context.Context, now time.Time) ([]domain.Job, error) {
+	return r.listWhere(ctx, `status = 'active' AND expires_at <= $1`, now)
+}
+
+// ListExpiringBefore returns active jobs expiring before the given time whose
+// recruiters haven't been reminded yet.
+func (r *JobRepository) ListExpiringBefore(ctx context.Context, before time.Time) ([]domain.Job, error) {
+	return r.listWhere(ctx, `status = 'active' AND expires_at <= $1 AND expiry_reminder_sent_at IS NULL`, before)
+}
 
-	// Then delete the job
-	jobQuery := `DELETE FROM jobs WHERE id = $1`
-	_, err = r.db.ExecContext(ctx, jobQuery, id)
+func (r *JobRepository) MarkExpiryReminderSent(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE jobs
+        SET expiry_reminder_sent_at = CURRENT_TIMESTAMP
+        WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, id)
 	return err
 }
 
+// Renew moves a job's expiry date, clears any reminder already sent for the
+// old date and sets the status the renewal leaves the job in, all in one
+// write. The change is recorded as a renewal.
+func (r *JobRepository) Renew(ctx context.Context, id uuid.UUID, expiresAt time.Time, status string, changedBy *uuid.UUID) error {
+	query := `
+        UPDATE jobs
+        SET expires_at = $1, expiry_reminder_sent_at = NULL,
+            status = $2::varchar,
+            published_at = CASE WHEN $2::varchar = 'active'
+                THEN COALESCE(published_at, CURRENT_TIMESTAMP) ELSE published_at END,
+            version = version + 1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $3`
+
+	return r.writeWithRevision(ctx, id, domain.RevisionRenewed, changedBy, query, expiresAt, status, id)
+}
+
+// Unschedule clears the publish time of a draft the scheduler couldn't
+// publish, so it isn't picked up again.
+func (r *JobRepository) Unschedule(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE jobs
+        SET publish_at = NULL,
+            version = version + 1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'draft'`
+
+	return r.writeWithRevision(ctx, id, domain.RevisionUnscheduled, nil, query, id)
+}
+
+func (r *JobRepository) listWhere(ctx context.Context, condition string, args ...interface{}) ([]domain.Job, error) {
+	query := `
+        SELECT ` + jobColumns + `
+        FROM jobs
+        WHERE deleted_at IS NULL AND ` + condition
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []domain.Job
+	for rows.Next() {
+		var job domain.Job
+		if err := scanJob(rows, &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// ListDuplicateCandidates returns the postings new jobs are checked against
+// for duplicates: everything that isn't closed or rejected, optionally
+// limited to one company.
+func (r *JobRepository) ListDuplicateCandidates(ctx context.Context, companyID *uuid.UUID) ([]domain.Job, error) {
+	if companyID != nil {
+		return r.listWhere(ctx, `status NOT IN ('closed', 'rejected') AND company_id = $1`, *companyID)
+	}
+	return r.listWhere(ctx, `status NOT IN ('closed', 'rejected')`)
+}
+
+// KnownBadTexts returns the content of the postings most recently rejected
+// as fraud, which fraud screening compares new postings against. Deleted postings are
+// kept in the sample so that deleting a rejected scam doesn't hide it.
+func (r *JobRepository) KnownBadTexts(ctx context.Context) ([]screening.KnownBad, error) {
+	query := `
+        SELECT id, title || ' ' || description
+        FROM jobs
+        WHERE rejected_as_fraud
+        ORDER BY updated_at DESC
+        LIMIT 500`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var texts []screening.KnownBad
+	for rows.Next() {
+		var text screening.KnownBad
+		if err := rows.Scan(&text.JobID, &text.Text); err != nil {
+			return nil, err
+		}
+		texts = append(texts, text)
+	}
+	return texts, rows.Err()
+}
+
+// Delete moves a job to the trash. It stays restorable until PurgeDeleted
+// removes it for good.
+func (r *JobRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE jobs
+        SET deleted_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND deleted_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// GetDeletedByID returns a job from the trash, or nil if the job doesn't
+// exist or hasn't been deleted.
+func (r *JobRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	job := &domain.Job{}
+	query := `
+        SELECT ` + jobColumns + `
+        FROM jobs
+        WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	err := scanJob(r.db.QueryRowContext(ctx, query, id), job)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListDeleted returns a company's jobs in the trash, most recently deleted
+// first.
+func (r *JobRepository) ListDeleted(ctx context.Context, companyID uuid.UUID) ([]domain.Job, error) {
+	query := `
+        SELECT ` + jobColumns + `
+        FROM jobs
+        WHERE company_id = $1 AND deleted_at IS NOT NULL
+        ORDER BY deleted_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []domain.Job
+	for rows.Next() {
+		var job domain.Job
+		if err := scanJob(rows, &job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *JobRepository) Restore(ctx context.Context, id uuid.UUID, changedBy *uuid.UUID) error {
+	query := `
+        UPDATE jobs
+        SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1`
+
+	return r.writeWithRevision(ctx, id, domain.RevisionUndeleted, changedBy, query, id)
+}
+
+// PurgeDeleted permanently removes jobs deleted before the given time along
+// with everything that refers to them.
+func (r *JobRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	purged := `SELECT id FROM jobs WHERE deleted_at IS NOT NULL AND deleted_at <= $1`
+	for _, query := range []string{
+		`DELETE FROM application_status_history WHERE application_id IN (SELECT id FROM applications WHERE job_id IN (` + purged + `))`,
+		`DELETE FROM applications WHERE job_id IN (` + purged + `)`,
+		`DELETE FROM job_revisions WHERE job_id IN (` + purged + `)`,
+		`DELETE FROM job_apply_clicks WHERE job_id IN (` + purged + `)`,
+		`DELETE FROM job_engagement_events WHERE job_id IN (` + purged + `)`,
+		`DELETE FROM job_not_interested WHERE job_id IN (` + purged + `)`,
+		`DELETE FROM notifications WHERE job_id IN (` + purged + `)`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, before); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM jobs WHERE deleted_at IS NOT NULL AND deleted_at <= $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), tx.Commit()
+}
+
 func (r *JobRepository) GetJobAnalytics(ctx context.Context, companyID uuid.UUID) (*domain.JobAnalytics, error) {
 	analytics := &domain.JobAnalytics{}
 
@@ -213,7 +626,7 @@ func (r *JobRepository) GetJobAnalytics(ctx context.Context, companyID uuid.UUID
 	activeJobsQuery := `
         SELECT COUNT(*) 
         FROM jobs 
-        WHERE company_id = $1 AND status = 'active'`
+        WHERE company_id = $1 AND status = 'active' AND deleted_at IS NULL`
 	err := r.db.QueryRowContext(ctx, activeJobsQuery, companyID).Scan(&analytics.ActiveJobs)
 	if err != nil {
 		return nil, err
@@ -224,7 +637,7 @@ func (r *JobRepository) GetJobAnalytics(ctx context.Context, companyID uuid.UUID
         SELECT COUNT(*) 
         FROM applications a 
         JOIN jobs j ON a.job_id = j.id 
-        WHERE j.company_id = $1`
+        WHERE j.company_id = $1 AND j.deleted_at IS NULL`
 	err = r.db.QueryRowContext(ctx, applicationsQuery, companyID).Scan(&analytics.TotalApplications)
 	if err != nil {
 		return nil, err
@@ -235,7 +648,7 @@ func (r *JobRepository) GetJobAnalytics(ctx context.Context, companyID uuid.UUID
         SELECT a.status, COUNT(*) 
         FROM applications a 
         JOIN jobs j ON a.job_id = j.id 
-        WHERE j.company_id = $1 
+        WHERE j.company_id = $1 AND j.deleted_at IS NULL
         GROUP BY a.status`
 	rows, err := r.db.QueryContext(ctx, statusQuery, companyID)
 	if err != nil {
@@ -265,8 +678,10 @@ func (r *JobRepository) BulkCreate(ctx context.Context, jobs []domain.Job) error
 	query := `
         INSERT INTO jobs (
             id, title, description, company_id, location, salary_range,
-            job_type, experience_level, skills, status
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+            job_type, experience_level, skills, status, risk_score, risk_reasons,
+            publish_at, published_at, expires_at, application_deadline,
+            openings, auto_reject_remaining, apply_method, apply_url
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`
 
 	stmt, err := tx.PrepareContext(ctx, query)
 	if err != nil {
@@ -275,7 +690,8 @@ func (r *JobRepository) BulkCreate(ctx context.Context, jobs []domain.Job) error
 	}
 	defer stmt.Close()
 
-	for _, job := range jobs {
+	for i := range jobs {
+		job := &jobs[i]
 		_, err = stmt.ExecContext(ctx,
 			job.ID,
 			job.Title,
@@ -287,11 +703,26 @@ func (r *JobRepository) BulkCreate(ctx context.Context, jobs []domain.Job) error
 			job.ExperienceLevel,
 			pq.Array(job.Skills),
 			job.Status,
+			job.RiskScore,
+			pq.Array(job.RiskReasons),
+			job.PublishAt,
+			publishedAt(job),
+			job.ExpiresAt,
+			job.ApplicationDeadline,
+			job.Openings,
+			job.AutoRejectRemaining,
+			job.ApplyMethod,
+			job.ApplyURL,
 		)
 		if err != nil {
 			tx.Rollback()
 			return err
 		}
+		job.Version = 1
+		if err := recordRevision(ctx, tx, job.ID, domain.RevisionCreated, &job.CompanyID); err != nil {
+			tx.Rollback()
+			return err
+		}
 	}
 
 	return tx.Commit()
@@ -299,99 +730,110 @@ func (r *JobRepository) BulkCreate(ctx context.Context, jobs []domain.Job) error
 
 func (r *JobRepository) GetApplicationInsights(ctx context.Context, jobID uuid.UUID) (*domain.JobApplicationInsights, error) {
 	insights := &domain.JobApplicationInsights{
-		JobID: jobID,
+		JobID:                jobID,
+		ApplicationsByStatus: make(map[string]int),
 	}
 
-	// Get application statistics
+	// Count applications by whatever statuses they are in
 	statsQuery := `
-        SELECT 
-            COUNT(*) as total_applications,
-            COUNT(CASE WHEN status = 'pending' THEN 1 END) as pending_applications,
-            COUNT(CASE WHEN status = 'reviewed' THEN 1 END) as reviewed_applications,
-            COUNT(CASE WHEN status = 'interviewed' THEN 1 END) as interviewed_applications,
-            COUNT(CASE WHEN status = 'accepted' THEN 1 END) as accepted_applications,
-            COUNT(CASE WHEN status = 'rejected' THEN 1 END) as rejected_applications
+        SELECT status, COUNT(*)
         FROM applications
-        WHERE job_id = $1`
-
-	err := r.db.QueryRowContext(ctx, statsQuery, jobID).Scan(
-		&insights.TotalApplications,
-		&insights.PendingApplications,
-		&insights.ReviewedApplications,
-		&insights.InterviewedApplications,
-		&insights.AcceptedApplications,
-		&insights.RejectedApplications,
-	)
+        WHERE job_id = $1
+        GROUP BY status`
+
+	rows, err := r.db.QueryContext(ctx, statsQuery, jobID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	// Get average experience of applicants
-	expQuery := `
-        SELECT AVG(years_of_experience) 
-        FROM applications 
-        WHERE job_id = $1`
-	err = r.db.QueryRowContext(ctx, expQuery, jobID).Scan(&insights.AverageExperience)
-	if err != nil {
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		insights.ApplicationsByStatus[status] = count
+		insights.TotalApplications += count
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
 	return insights, nil
 }
 
-func (r *JobRepository) GetRecommendedCandidates(ctx context.Context, jobID uuid.UUID) ([]domain.RecommendedCandidate, error) {
-	query := `
-        WITH job_skills AS (
-            SELECT skills FROM jobs WHERE id = $1
-        )
-        SELECT 
-            u.id,
-            u.name,
-            u.email,
-            u.years_of_experience,
-            array_agg(DISTINCT us.skill) as skills,
-            COUNT(DISTINCT us.skill) * 100.0 / (
-                SELECT array_length(skills, 1) FROM job_skills
-            ) as skill_match_percentage
-        FROM 
-            users u
-            JOIN user_skills us ON u.id = us.user_id
-            CROSS JOIN job_skills
-            WHERE us.skill = ANY((SELECT skills FROM job_skills))
-            AND u.role = 'job_seeker'
-            AND NOT EXISTS (
-                SELECT 1 FROM applications a 
-                WHERE a.user_id = u.id AND a.job_id = $1
-            )
-        GROUP BY u.id, u.name, u.email, u.years_of_experience
-        HAVING COUNT(DISTINCT us.skill) >= (
-            SELECT array_length(skills, 1) * 0.5 FROM job_skills
-        )
-        ORDER BY skill_match_percentage DESC
-        LIMIT 10`
-
-	rows, err := r.db.QueryContext(ctx, query, jobID)
+// ListApplicantBackgrounds returns the profile skills, employment history and
+// degrees of everyone who applied to the job, leaving out profiles its
+// company may not see.
+func (r *JobRepository) ListApplicantBackgrounds(ctx context.Context, jobID uuid.UUID) ([]domain.ApplicantBackground, error) {
+	applicants := `
+        SELECT u.id FROM users u
+        WHERE u.id IN (SELECT applicant_id FROM applications WHERE job_id = $1)
+          AND ` + visibleToCompany("(SELECT company_id FROM jobs WHERE id = $1)")
+
+	var backgrounds []domain.ApplicantBackground
+	index := make(map[uuid.UUID]int)
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, COALESCE(skills, '{}')
+        FROM users
+        WHERE id IN (`+applicants+`)`, jobID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	for rows.Next() {
+		var b domain.ApplicantBackground
+		if err := rows.Scan(&b.UserID, pq.Array(&b.Skills)); err != nil {
+			return nil, err
+		}
+		index[b.UserID] = len(backgrounds)
+		backgrounds = append(backgrounds, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	var candidates []domain.RecommendedCandidate
+	rows, err = r.db.QueryContext(ctx, `
+        SELECT user_id, start_date, end_date
+        FROM user_employment_history
+        WHERE user_id IN (`+applicants+`)`, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 	for rows.Next() {
-		var c domain.RecommendedCandidate
-		err := rows.Scan(
-			&c.UserID,
-			&c.Name,
-			&c.Email,
-			&c.YearsOfExperience,
-			pq.Array(&c.Skills),
-			&c.SkillMatchPercentage,
-		)
-		if err != nil {
+		var userID uuid.UUID
+		var emp domain.EmploymentHistory
+		if err := rows.Scan(&userID, &emp.StartDate, &emp.EndDate); err != nil {
 			return nil, err
 		}
-		candidates = append(candidates, c)
+		if i, ok := index[userID]; ok {
+			backgrounds[i].Employment = append(backgrounds[i].Employment, emp)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return candidates, nil
+	rows, err = r.db.QueryContext(ctx, `
+        SELECT user_id, degree
+        FROM user_education_history
+        WHERE user_id IN (`+applicants+`)`, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userID uuid.UUID
+		var degree string
+		if err := rows.Scan(&userID, &degree); err != nil {
+			return nil, err
+		}
+		if i, ok := index[userID]; ok {
+			backgrounds[i].Degrees = append(backgrounds[i].Degrees, degree)
+		}
+	}
+	return backgrounds, rows.Err()
 }
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// ListAppliedJobs returns the jobs the user has applied to.
+func (r *JobRepository) ListAppliedJobs(ctx context.Context, userID uuid.UUID) ([]domain.Job, error) {
+	return r.listWhere(ctx, `id IN (SELECT job_id FROM applications WHERE applicant_id = $1)`, userID)
+}
+
+// MarkNotInterested records that the user doesn't want to see the job or
+// jobs like it.
+func (r *JobRepository) MarkNotInterested(ctx context.Context, userID, jobID uuid.UUID) error {
+	query := `
+        INSERT INTO job_not_interested (user_id, job_id)
+        VALUES ($1, $2)
+        ON CONFLICT DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query, userID, jobID)
+	return err
+}
+
+func (r *JobRepository) ClearNotInterested(ctx context.Context, userID, jobID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM job_not_interested WHERE user_id = $1 AND job_id = $2`, userID, jobID)
+	return err
+}
+
+// ListNotInterested returns the jobs the user marked as not interested.
+func (r *JobRepository) ListNotInterested(ctx context.Context, userID uuid.UUID) ([]domain.Job, error) {
+	return r.listWhere(ctx, `id IN (SELECT job_id FROM job_not_interested WHERE user_id = $1)`, userID)
+}
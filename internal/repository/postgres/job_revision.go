@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+type JobRevisionRepository struct {
+	db *sql.DB
+}
+
+func NewJobRevisionRepository(db *sql.DB) *JobRevisionRepository {
+	return &JobRevisionRepository{db: db}
+}
+
+// recordRevision snapshots a job as written so far in tx into its revision
+// history, numbered one past its latest revision. The job's row is locked
+// first, so concurrent writes to the same job take their numbers in turn.
+func recordRevision(ctx context.Context, tx *sql.Tx, jobID uuid.UUID, changeType string, changedBy *uuid.UUID) error {
+	job := &domain.Job{}
+	err := scanJob(tx.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = $1 FOR UPDATE`, jobID), job)
+	if err != nil {
+		return err
+	}
+	snapshot, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO job_revisions (id, job_id, revision, change_type, changed_by, snapshot)
+        SELECT $1, $2, COALESCE(MAX(revision), 0) + 1, $3, $4, $5
+        FROM job_revisions
+        WHERE job_id = $2`,
+		uuid.New(), jobID, changeType, changedBy, snapshot)
+	return err
+}
+
+// writeWithRevision runs an update of one job and records the resulting
+// revision in the same transaction.
+func (r *JobRepository) writeWithRevision(ctx context.Context, jobID uuid.UUID, changeType string, changedBy *uuid.UUID, query string, args ...interface{}) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return err
+	}
+	if err := recordRevision(ctx, tx, jobID, changeType, changedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *JobRevisionRepository) List(ctx context.Context, jobID uuid.UUID) ([]domain.JobRevision, error) {
+	query := `
+        SELECT id, job_id, revision, change_type, changed_by, snapshot, created_at
+        FROM job_revisions
+        WHERE job_id = $1
+        ORDER BY revision DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []domain.JobRevision
+	for rows.Next() {
+		var revision domain.JobRevision
+		if err := scanJobRevision(rows, &revision); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
+
+func (r *JobRevisionRepository) Get(ctx context.Context, jobID uuid.UUID, revision int) (*domain.JobRevision, error) {
+	query := `
+        SELECT id, job_id, revision, change_type, changed_by, snapshot, created_at
+        FROM job_revisions
+        WHERE job_id = $1 AND revision = $2`
+
+	rev := &domain.JobRevision{}
+	err := scanJobRevision(r.db.QueryRowContext(ctx, query, jobID, revision), rev)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
+func scanJobRevision(row rowScanner, revision *domain.JobRevision) error {
+	var snapshot []byte
+	if err := row.Scan(
+		&revision.ID,
+		&revision.JobID,
+		&revision.Revision,
+		&revision.ChangeType,
+		&revision.ChangedBy,
+		&snapshot,
+		&revision.CreatedAt,
+	); err != nil {
+		return err
+	}
+	return json.Unmarshal(snapshot, &revision.Snapshot)
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+type JobTemplateRepository struct {
+	db *sql.DB
+}
+
+func NewJobTemplateRepository(db *sql.DB) *JobTemplateRepository {
+	return &JobTemplateRepository{db: db}
+}
+
+const jobTemplateColumns = `id, company_id, name, title, description, location, salary_range,
+        job_type, experience_level, skills, openings, created_at, updated_at`
+
+func scanJobTemplate(row rowScanner, t *domain.JobTemplate) error {
+	return row.Scan(
+		&t.ID,
+		&t.CompanyID,
+		&t.Name,
+		&t.Title,
+		&t.Description,
+		&t.Location,
+		&t.SalaryRange,
+		&t.JobType,
+		&t.ExperienceLevel,
+		pq.Array(&t.Skills),
+		&t.Openings,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+}
+
+func (r *JobTemplateRepository) Create(ctx context.Context, t *domain.JobTemplate) error {
+	query := `
+        INSERT INTO job_templates (
+            id, company_id, name, title, description, location, salary_range,
+            job_type, experience_level, skills, openings
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        RETURNING created_at, updated_at`
+
+	return r.db.QueryRowContext(
+		ctx,
+		query,
+		t.ID,
+		t.CompanyID,
+		t.Name,
+		t.Title,
+		t.Description,
+		t.Location,
+		t.SalaryRange,
+		t.JobType,
+		t.ExperienceLevel,
+		pq.Array(t.Skills),
+		t.Openings,
+	).Scan(&t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *JobTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.JobTemplate, error) {
+	t := &domain.JobTemplate{}
+	query := `
+        SELECT ` + jobTemplateColumns + `
+        FROM job_templates
+        WHERE id = $1`
+
+	err := scanJobTemplate(r.db.QueryRowContext(ctx, query, id), t)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *JobTemplateRepository) ListByCompany(ctx context.Context, companyID uuid.UUID) ([]domain.JobTemplate, error) {
+	query := `
+        SELECT ` + jobTemplateColumns + `
+        FROM job_templates
+        WHERE company_id = $1
+        ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []domain.JobTemplate
+	for rows.Next() {
+		var t domain.JobTemplate
+		if err := scanJobTemplate(rows, &t); err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+func (r *JobTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM job_templates WHERE id = $1`, id)
+	return err
+}
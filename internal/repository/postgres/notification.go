@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, n *domain.Notification) error {
+	query := `
+        INSERT INTO notifications (id, user_id, type, title, message, job_id)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at`
+
+	return r.db.QueryRowContext(
+		ctx,
+		query,
+		n.ID,
+		n.UserID,
+		n.Type,
+		n.Title,
+		n.Message,
+		n.JobID,
+	).Scan(&n.CreatedAt)
+}
+
+func (r *NotificationRepository) List(ctx context.Context, filter domain.NotificationFilter) ([]domain.Notification, int, error) {
+	where := "WHERE user_id = $1"
+	if filter.UnreadOnly {
+		where += " AND read_at IS NULL"
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM notifications "+where, filter.UserID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+        SELECT id, user_id, type, title, message, job_id, read_at, created_at
+        FROM notifications
+        %s
+        ORDER BY created_at DESC
+        LIMIT $2 OFFSET $3`, where)
+
+	rows, err := r.db.QueryContext(ctx, query, filter.UserID, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var notifications []domain.Notification
+	for rows.Next() {
+		var n domain.Notification
+		if err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.Type,
+			&n.Title,
+			&n.Message,
+			&n.JobID,
+			&n.ReadAt,
+			&n.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, total, rows.Err()
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, id, userID uuid.UUID) error {
+	query := `
+        UPDATE notifications
+        SET read_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND user_id = $2 AND read_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, id, userID)
+	return err
+}
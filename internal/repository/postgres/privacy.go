@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// visibleToCompany is the SQL condition for the profile of users row u being
+// visible to a company, given as an SQL expression for its ID. Companies see
+// public and recruiter-only profiles, and those of job seekers who applied to
+// them, unless the job seeker blocked them or a company of the same name.
+// Empty company names never match.
+func visibleToCompany(company string) string {
+	return fmt.Sprintf(`(
+            (u.profile_visibility IN ('public', 'recruiters')
+             OR (u.profile_visibility = 'applied' AND EXISTS (
+                 SELECT 1 FROM applications va JOIN jobs vj ON vj.id = va.job_id
+                 WHERE va.applicant_id = u.id AND vj.company_id = %[1]s)))
+            AND NOT EXISTS (
+                SELECT 1 FROM user_blocked_companies vb JOIN users vc ON vc.id = vb.company_id
+                WHERE vb.user_id = u.id
+                  AND (vb.company_id = %[1]s
+                       OR lower(NULLIF(vc.company_name, '')) = (SELECT lower(NULLIF(company_name, '')) FROM users WHERE id = %[1]s))))`, company)
+}
+
+// VisibleToCompany reports whether the company may see the user's profile.
+func (r *UserRepository) VisibleToCompany(ctx context.Context, userID, companyID uuid.UUID) (bool, error) {
+	var visible bool
+	err := r.db.QueryRowContext(ctx, `
+        SELECT EXISTS (SELECT 1 FROM users u WHERE u.id = $1 AND `+visibleToCompany("$2")+`)`,
+		userID, companyID).Scan(&visible)
+	return visible, err
+}
+
+func (r *UserRepository) GetPrivacySettings(ctx context.Context, userID uuid.UUID) (*domain.PrivacySettings, error) {
+	settings := &domain.PrivacySettings{}
+	err := r.db.QueryRowContext(ctx, `
+        SELECT profile_visibility, COALESCE(hidden_contact_fields, '{}'), open_to_work
+        FROM users
+        WHERE id = $1 AND deleted_at IS NULL`, userID).
+		Scan(&settings.ProfileVisibility, pq.Array(&settings.HiddenContactFields), &settings.OpenToWork)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT b.company_id, COALESCE(NULLIF(c.company_name, ''), c.full_name), b.created_at
+        FROM user_blocked_companies b
+        JOIN users c ON c.id = b.company_id
+        WHERE b.user_id = $1
+        ORDER BY b.created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings.BlockedCompanies = []domain.BlockedCompany{}
+	for rows.Next() {
+		var b domain.BlockedCompany
+		if err := rows.Scan(&b.CompanyID, &b.Name, &b.BlockedAt); err != nil {
+			return nil, err
+		}
+		settings.BlockedCompanies = append(settings.BlockedCompanies, b)
+	}
+	return settings, rows.Err()
+}
+
+// UpdatePrivacySettings saves the visibility, hidden contact fields and open
+// to work flag. Blocked companies are changed one at a time.
+func (r *UserRepository) UpdatePrivacySettings(ctx context.Context, userID uuid.UUID, settings *domain.PrivacySettings) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE users
+        SET profile_visibility = $2, hidden_contact_fields = $3, open_to_work = $4, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1`,
+		userID, settings.ProfileVisibility, pq.Array(settings.HiddenContactFields), settings.OpenToWork)
+	return err
+}
+
+func (r *UserRepository) BlockCompany(ctx context.Context, userID, companyID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO user_blocked_companies (user_id, company_id)
+        VALUES ($1, $2)
+        ON CONFLICT DO NOTHING`, userID, companyID)
+	return err
+}
+
+func (r *UserRepository) UnblockCompany(ctx context.Context, userID, companyID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+        DELETE FROM user_blocked_companies
+        WHERE user_id = $1 AND company_id = $2`, userID, companyID)
+	return err
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// NormalizeSkills rewrites the skills of every job, including deleted ones,
+// with normalize and returns how many changed. Like every other job write it
+// bumps the version of each changed job and records a revision.
+func (r *JobRepository) NormalizeSkills(ctx context.Context, normalize func([]string) []string) (int, error) {
+	return normalizeSkills(ctx, r.db, "jobs", normalize,
+		", version = version + 1, updated_at = CURRENT_TIMESTAMP",
+		func(tx *sql.Tx, id uuid.UUID) error {
+			return recordRevision(ctx, tx, id, domain.RevisionSkillsNormalized, nil)
+		})
+}
+
+// NormalizeSkills rewrites the skills of every template with normalize and
+// returns how many changed.
+func (r *JobTemplateRepository) NormalizeSkills(ctx context.Context, normalize func([]string) []string) (int, error) {
+	return normalizeSkills(ctx, r.db, "job_templates", normalize, "", nil)
+}
+
+// NormalizeSkills rewrites the skills of every user with normalize and
+// returns how many changed.
+func (r *UserRepository) NormalizeSkills(ctx context.Context, normalize func([]string) []string) (int, error) {
+	return normalizeSkills(ctx, r.db, "users", normalize, "", nil)
+}
+
+// normalizeSkills rewrites the skills column of a table in one transaction,
+// updating only the rows whose skills change. set adds to the SET clause of
+// each update, and written, if not nil, runs in the transaction after it.
+func normalizeSkills(ctx context.Context, db *sql.DB, table string, normalize func([]string) []string,
+	set string, written func(tx *sql.Tx, id uuid.UUID) error) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+        SELECT id, skills FROM %s
+        WHERE skills IS NOT NULL AND cardinality(skills) > 0
+        FOR UPDATE`, table))
+	if err != nil {
+		return 0, err
+	}
+
+	changed := make(map[uuid.UUID][]string)
+	for rows.Next() {
+		var id uuid.UUID
+		var skills []string
+		if err := rows.Scan(&id, pq.Array(&skills)); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if normalized := normalize(skills); !slices.Equal(normalized, skills) {
+			changed[id] = normalized
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf(`UPDATE %s SET skills = $2%s WHERE id = $1`, table, set))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for id, skills := range changed {
+		if _, err := stmt.ExecContext(ctx, id, pq.Array(skills)); err != nil {
+			return 0, err
+		}
+		if written != nil {
+			if err := written(tx, id); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(changed), tx.Commit()
+}
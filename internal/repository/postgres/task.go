@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+type TaskRepository struct {
+	db *sql.DB
+}
+
+func NewTaskRepository(db *sql.DB) *TaskRepository {
+	return &TaskRepository{db: db}
+}
+
+const taskColumns = `id, owner_id, type, status, total, processed, failed, errors, result, error,
+        cancel_requested, payload, created_at, started_at, finished_at`
+
+func scanTask(row rowScanner, task *domain.Task) error {
+	var errs, result []byte
+	err := row.Scan(
+		&task.ID,
+		&task.OwnerID,
+		&task.Type,
+		&task.Status,
+		&task.Total,
+		&task.Processed,
+		&task.Failed,
+		&errs,
+		&result,
+		&task.Error,
+		&task.CancelRequested,
+		&task.Payload,
+		&task.CreatedAt,
+		&task.StartedAt,
+		&task.FinishedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if len(result) > 0 {
+		task.Result = result
+	}
+	if len(errs) > 0 {
+		return json.Unmarshal(errs, &task.Errors)
+	}
+	return nil
+}
+
+func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	query := `
+        INSERT INTO tasks (id, owner_id, type, status, total, payload)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at`
+
+	return r.db.QueryRowContext(
+		ctx,
+		query,
+		task.ID,
+		task.OwnerID,
+		task.Type,
+		task.Status,
+		task.Total,
+		[]byte(task.Payload),
+	).Scan(&task.CreatedAt)
+}
+
+func (r *TaskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	task := &domain.Task{}
+	query := `
+        SELECT ` + taskColumns + `
+        FROM tasks
+        WHERE id = $1`
+
+	err := scanTask(r.db.QueryRowContext(ctx, query, id), task)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ListQueued returns the IDs of tasks waiting to run, oldest first.
+func (r *TaskRepository) ListQueued(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM tasks WHERE status = 'queued' ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Start moves a queued task to running. It returns false when the task was
+// no longer queued, for example because it was cancelled.
+func (r *TaskRepository) Start(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE tasks
+        SET status = 'running', started_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'queued'`, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+func (r *TaskRepository) UpdateProgress(ctx context.Context, id uuid.UUID, total, processed, failed int, errs []domain.TaskItemError) error {
+	data, err := json.Marshal(errs)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+        UPDATE tasks
+        SET total = $1, processed = $2, failed = $3, errors = $4, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $5`, total, processed, failed, data, id)
+	return err
+}
+
+// Finish records a task's final status along with its result or error.
+func (r *TaskRepository) Finish(ctx context.Context, id uuid.UUID, status string, result []byte, taskErr *string) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE tasks
+        SET status = $1, result = $2, error = $3,
+            finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $4`, status, result, taskErr, id)
+	return err
+}
+
+// RequestCancel flags a task for cancellation. Queued tasks are cancelled
+// straight away; running ones stop at their next item.
+func (r *TaskRepository) RequestCancel(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE tasks
+        SET cancel_requested = TRUE,
+            status = CASE WHEN status = 'queued' THEN 'cancelled' ELSE status END,
+            finished_at = CASE WHEN status = 'queued' THEN CURRENT_TIMESTAMP ELSE finished_at END,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status IN ('queued', 'running')`, id)
+	return err
+}
+
+// FailInterrupted marks tasks left running by a previous process as failed.
+func (r *TaskRepository) FailInterrupted(ctx context.Context) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+        UPDATE tasks
+        SET status = 'failed', error = 'interrupted by a server restart',
+            finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+        WHERE status = 'running'`)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
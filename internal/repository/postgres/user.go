@@ -3,11 +3,13 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/zahidhasann88/job-board-api/internal/domain"
 )
 
@@ -22,8 +24,8 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
         INSERT INTO users (
-            id, email, password_hash, role, full_name, company_name, resume_url
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7)
+            id, email, password_hash, role, full_name, company_name, resume_url, verified
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
         RETURNING created_at, updated_at
     `
 
@@ -37,6 +39,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 		user.FullName,
 		user.CompanyName,
 		user.ResumeURL,
+		user.Verified,
 	).Scan(&user.CreatedAt, &user.UpdatedAt)
 }
 
@@ -44,9 +47,9 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 	user := &domain.User{}
 	query := `
         SELECT id, email, password_hash, role, full_name, company_name,
-               resume_url, created_at, updated_at
+               resume_url, verified, created_at, updated_at
         FROM users
-        WHERE id = $1
+        WHERE id = $1 AND deleted_at IS NULL
     `
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
@@ -57,6 +60,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 		&user.FullName,
 		&user.CompanyName,
 		&user.ResumeURL,
+		&user.Verified,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -74,9 +78,9 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	user := &domain.User{}
 	query := `
         SELECT id, email, password_hash, role, full_name, company_name, 
-               resume_url, created_at, updated_at
+               resume_url, verified, created_at, updated_at
         FROM users
-        WHERE email = $1
+        WHERE email = $1 AND deleted_at IS NULL
     `
 
 	err := r.db.QueryRowContext(ctx, query, email).Scan(
@@ -87,6 +91,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 		&user.FullName,
 		&user.CompanyName,
 		&user.ResumeURL,
+		&user.Verified,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -100,6 +105,136 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	return user, nil
 }
 
+// GetProfile returns a user with every profile detail, history and
+// certification.
+func (r *UserRepository) GetProfile(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user := &domain.User{}
+	var socialLinks, contactInfo []byte
+	query := `
+        SELECT id, email, role, full_name, company_name, resume_url, verified, created_at, updated_at,
+               COALESCE(skills, '{}'), experience, education, bio, profile_picture_url, location,
+               social_links, contact_info, open_to_work, profile_visibility,
+               COALESCE(hidden_contact_fields, '{}')
+        FROM users
+        WHERE id = $1 AND deleted_at IS NULL`
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&user.ID,
+		&user.Email,
+		&user.Role,
+		&user.FullName,
+		&user.CompanyName,
+		&user.ResumeURL,
+		&user.Verified,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		pq.Array(&user.Skills),
+		&user.Experience,
+		&user.Education,
+		&user.Bio,
+		&user.ProfilePictureURL,
+		&user.Location,
+		&socialLinks,
+		&contactInfo,
+		&user.OpenToWork,
+		&user.ProfileVisibility,
+		pq.Array(&user.HiddenContactFields),
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if socialLinks != nil {
+		if err := json.Unmarshal(socialLinks, &user.SocialLinks); err != nil {
+			return nil, err
+		}
+	}
+	if contactInfo != nil {
+		if err := json.Unmarshal(contactInfo, &user.ContactInfo); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.EmploymentHistory, err = r.employmentHistory(ctx, id); err != nil {
+		return nil, err
+	}
+	if user.EducationHistory, err = r.educationHistory(ctx, id); err != nil {
+		return nil, err
+	}
+	if user.Certifications, err = r.certifications(ctx, id); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *UserRepository) employmentHistory(ctx context.Context, userID uuid.UUID) ([]domain.EmploymentHistory, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, company, title, start_date, end_date, description
+        FROM user_employment_history
+        WHERE user_id = $1
+        ORDER BY start_date DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []domain.EmploymentHistory
+	for rows.Next() {
+		var emp domain.EmploymentHistory
+		if err := rows.Scan(&emp.ID, &emp.Company, &emp.Title, &emp.StartDate, &emp.EndDate, &emp.Description); err != nil {
+			return nil, err
+		}
+		history = append(history, emp)
+	}
+	return history, rows.Err()
+}
+
+func (r *UserRepository) educationHistory(ctx context.Context, userID uuid.UUID) ([]domain.EducationHistory, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, institution, degree, field, start_date, end_date
+        FROM user_education_history
+        WHERE user_id = $1
+        ORDER BY start_date DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []domain.EducationHistory
+	for rows.Next() {
+		var edu domain.EducationHistory
+		if err := rows.Scan(&edu.ID, &edu.Institution, &edu.Degree, &edu.Field, &edu.StartDate, &edu.EndDate); err != nil {
+			return nil, err
+		}
+		history = append(history, edu)
+	}
+	return history, rows.Err()
+}
+
+func (r *UserRepository) certifications(ctx context.Context, userID uuid.UUID) ([]domain.Certification, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, name, authority, issue_date, expiry_date, credential_id
+        FROM user_certifications
+        WHERE user_id = $1
+        ORDER BY issue_date DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var certifications []domain.Certification
+	for rows.Next() {
+		var cert domain.Certification
+		if err := rows.Scan(&cert.ID, &cert.Name, &cert.Authority, &cert.IssueDate, &cert.ExpiryDate, &cert.CredentialID); err != nil {
+			return nil, err
+		}
+		certifications = append(certifications, cert)
+	}
+	return certifications, rows.Err()
+}
+
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
         UPDATE users
@@ -119,18 +254,171 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	return err
 }
 
+// Delete moves a user and the jobs they posted to the trash. The jobs share
+// the user's deleted_at so that restoring the user brings back exactly those
+// jobs.
 func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var deletedAt time.Time
+	err = tx.QueryRowContext(ctx, `
+        UPDATE users
+        SET deleted_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND deleted_at IS NULL
+        RETURNING deleted_at`, id).Scan(&deletedAt)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE jobs
+        SET deleted_at = $1
+        WHERE company_id = $2 AND deleted_at IS NULL`, deletedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetDeletedByID returns a user from the trash, or nil if the user doesn't
+// exist or hasn't been deleted.
+func (r *UserRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.getDeleted(ctx, "id = $1", id)
+}
+
+// GetDeletedByEmail returns the deleted user still holding an email address,
+// which stays taken until the user is purged.
+func (r *UserRepository) GetDeletedByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.getDeleted(ctx, "email = $1", email)
+}
+
+func (r *UserRepository) getDeleted(ctx context.Context, where string, arg interface{}) (*domain.User, error) {
+	user := &domain.User{}
+	query := `
+        SELECT id, email, role, full_name, company_name, resume_url, verified,
+               created_at, updated_at, deleted_at
+        FROM users
+        WHERE ` + where + ` AND deleted_at IS NOT NULL
+    `
+
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&user.ID,
+		&user.Email,
+		&user.Role,
+		&user.FullName,
+		&user.CompanyName,
+		&user.ResumeURL,
+		&user.Verified,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.DeletedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Restore brings a user back from the trash together with the jobs that were
+// deleted along with them.
+func (r *UserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE jobs j
+        SET deleted_at = NULL, version = j.version + 1, updated_at = CURRENT_TIMESTAMP
+        FROM users u
+        WHERE u.id = $1 AND j.company_id = u.id AND j.deleted_at = u.deleted_at`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE users
+        SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// PurgeDeleted permanently removes users deleted before the given time, the
+// jobs they posted and everything that refers to either.
+func (r *UserRepository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	purged := `SELECT id FROM users WHERE deleted_at IS NOT NULL AND deleted_at <= $1`
+	jobs := `SELECT id FROM jobs WHERE company_id IN (` + purged + `)`
+	for _, query := range []string{
+		`DELETE FROM application_status_history WHERE application_id IN (SELECT id FROM applications WHERE applicant_id IN (` + purged + `) OR job_id IN (` + jobs + `))`,
+		`DELETE FROM applications WHERE applicant_id IN (` + purged + `) OR job_id IN (` + jobs + `)`,
+		`DELETE FROM job_revisions WHERE job_id IN (` + jobs + `)`,
+		`DELETE FROM job_apply_clicks WHERE job_id IN (` + jobs + `)`,
+		`DELETE FROM job_engagement_events WHERE job_id IN (` + jobs + `)`,
+		`DELETE FROM job_not_interested WHERE user_id IN (` + purged + `) OR job_id IN (` + jobs + `)`,
+		`DELETE FROM notifications WHERE user_id IN (` + purged + `) OR job_id IN (` + jobs + `)`,
+		`DELETE FROM jobs WHERE company_id IN (` + purged + `)`,
+		`DELETE FROM user_certifications WHERE user_id IN (` + purged + `)`,
+		`DELETE FROM user_education_history WHERE user_id IN (` + purged + `)`,
+		`DELETE FROM user_employment_history WHERE user_id IN (` + purged + `)`,
+		`DELETE FROM user_analytics WHERE user_id IN (` + purged + `)`,
+		`DELETE FROM user_blocked_companies WHERE user_id IN (` + purged + `) OR company_id IN (` + purged + `)`,
+		`DELETE FROM user_profile_views WHERE user_id IN (` + purged + `)`,
+		`UPDATE user_profile_views SET company_id = NULL WHERE company_id IN (` + purged + `)`,
+		`DELETE FROM job_templates WHERE company_id IN (` + purged + `)`,
+		`DELETE FROM tasks WHERE owner_id IN (` + purged + `)`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, before); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at <= $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), tx.Commit()
+}
+
+func (r *UserRepository) SetVerified(ctx context.Context, id uuid.UUID, verified bool) error {
 	query := `
-        DELETE FROM users
-        WHERE id = $1
+        UPDATE users
+        SET verified = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2
     `
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := r.db.ExecContext(ctx, query, verified, id)
 	return err
 }
 
 func (r *UserRepository) CalculateProfileCompleteness(ctx context.Context, userID uuid.UUID) (float64, error) {
-	user, err := r.GetByID(ctx, userID)
+	user, err := r.GetProfile(ctx, userID)
 	if err != nil {
 		return 0, err
 	}
@@ -176,6 +464,16 @@ func (r *UserRepository) UpdateProfileDetails(ctx context.Context, userID uuid.U
 	argCounter := 1
 
 	for key, value := range updates {
+		switch v := value.(type) {
+		case []string:
+			value = pq.Array(v)
+		case *domain.SocialLinks, *domain.ContactInfo:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			value = data
+		}
 		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", key, argCounter))
 		args = append(args, value)
 		argCounter++
@@ -227,19 +525,81 @@ func (r *UserRepository) GetUserAnalytics(ctx context.Context, userID uuid.UUID)
 	return analytics, nil
 }
 
-func (r *UserRepository) IncrementProfileView(ctx context.Context, userID uuid.UUID) error {
+// IncrementProfileView records a view of a profile and counts it in the
+// user's analytics, unless the visitor already viewed the profile that day.
+func (r *UserRepository) IncrementProfileView(ctx context.Context, view *domain.ProfileView) error {
 	query := `
+		WITH viewed AS (
+			INSERT INTO user_profile_views (user_id, day, visitor_hash, company_id)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT DO NOTHING
+			RETURNING user_id
+		)
 		INSERT INTO user_analytics (user_id, profile_views, last_active)
-		VALUES ($1, 1, CURRENT_TIMESTAMP)
+		SELECT user_id, 1, CURRENT_TIMESTAMP FROM viewed
 		ON CONFLICT (user_id) DO UPDATE 
 		SET profile_views = user_analytics.profile_views + 1,
 			last_active = CURRENT_TIMESTAMP
 	`
 
-	_, err := r.db.ExecContext(ctx, query, userID)
+	_, err := r.db.ExecContext(ctx, query, view.UserID, view.Day, view.VisitorHash, view.CompanyID)
 	return err
 }
 
+// ProfileViewsByDay returns the distinct visitors of a profile per day
+// between from and to, both inclusive. Days without views are left out.
+func (r *UserRepository) ProfileViewsByDay(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.ProfileViewDay, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT day, COUNT(*)
+        FROM user_profile_views
+        WHERE user_id = $1 AND day BETWEEN $2 AND $3
+        GROUP BY day
+        ORDER BY day`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []domain.ProfileViewDay
+	for rows.Next() {
+		var day time.Time
+		var d domain.ProfileViewDay
+		if err := rows.Scan(&day, &d.Views); err != nil {
+			return nil, err
+		}
+		d.Date = day.Format(time.DateOnly)
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
+
+// ProfileViewers returns the companies whose recruiters viewed a profile
+// between from and to, both inclusive, most views first. Recruiters of the
+// same company are counted together.
+func (r *UserRepository) ProfileViewers(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.ProfileViewer, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT COALESCE(NULLIF(c.company_name, ''), c.full_name), COUNT(*), MAX(v.viewed_at)
+        FROM user_profile_views v
+        JOIN users c ON c.id = v.company_id
+        WHERE v.user_id = $1 AND v.day BETWEEN $2 AND $3
+        GROUP BY 1
+        ORDER BY 2 DESC, 3 DESC`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	viewers := []domain.ProfileViewer{}
+	for rows.Next() {
+		var v domain.ProfileViewer
+		if err := rows.Scan(&v.Company, &v.Views, &v.LastViewedAt); err != nil {
+			return nil, err
+		}
+		viewers = append(viewers, v)
+	}
+	return viewers, rows.Err()
+}
+
 func (r *UserRepository) UpdateCertifications(ctx context.Context, userID uuid.UUID, certifications []domain.Certification) error {
 	// Begin a transaction
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -353,3 +713,97 @@ func (r *UserRepository) UpdateEmploymentHistory(ctx context.Context, userID uui
 	// Commit the transaction
 	return tx.Commit()
 }
+
+// ListCandidateProfiles returns the matching profiles of job seekers who
+// haven't applied to the given job and whose profiles its company may see.
+// Emails the job seekers hid are left empty.
+func (r *UserRepository) ListCandidateProfiles(ctx context.Context, notAppliedTo uuid.UUID) ([]domain.CandidateProfile, error) {
+	candidates := `
+        SELECT u.id FROM users u
+        WHERE u.role = 'job_seeker' AND u.deleted_at IS NULL
+          AND ` + visibleToCompany("(SELECT company_id FROM jobs WHERE id = $1)") + `
+          AND NOT EXISTS (SELECT 1 FROM applications a WHERE a.applicant_id = u.id AND a.job_id = $1)`
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT u.id, u.full_name,
+               CASE WHEN 'email' = ANY(u.hidden_contact_fields) THEN '' ELSE u.email END,
+               COALESCE(u.skills, '{}'), u.location, COALESCE(ua.profile_completeness, 0)
+        FROM users u
+        LEFT JOIN user_analytics ua ON ua.user_id = u.id
+        WHERE u.id IN (`+candidates+`)`, notAppliedTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []domain.CandidateProfile
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var p domain.CandidateProfile
+		if err := rows.Scan(&p.UserID, &p.FullName, &p.Email, pq.Array(&p.Skills), &p.Location, &p.ProfileCompleteness); err != nil {
+			return nil, err
+		}
+		index[p.UserID] = len(profiles)
+		profiles = append(profiles, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.QueryContext(ctx, `
+        SELECT user_id, company, title, start_date, end_date
+        FROM user_employment_history
+        WHERE user_id IN (`+candidates+`)`, notAppliedTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userID uuid.UUID
+		var emp domain.EmploymentHistory
+		if err := rows.Scan(&userID, &emp.Company, &emp.Title, &emp.StartDate, &emp.EndDate); err != nil {
+			return nil, err
+		}
+		if i, ok := index[userID]; ok {
+			profiles[i].EmploymentHistory = append(profiles[i].EmploymentHistory, emp)
+		}
+	}
+	return profiles, rows.Err()
+}
+
+// GetCandidateProfile returns the matching profile of a user.
+func (r *UserRepository) GetCandidateProfile(ctx context.Context, userID uuid.UUID) (*domain.CandidateProfile, error) {
+	p := &domain.CandidateProfile{}
+	query := `
+        SELECT u.id, u.full_name, u.email, COALESCE(u.skills, '{}'), u.location,
+               COALESCE(ua.profile_completeness, 0)
+        FROM users u
+        LEFT JOIN user_analytics ua ON ua.user_id = u.id
+        WHERE u.id = $1 AND u.deleted_at IS NULL`
+
+	err := r.db.QueryRowContext(ctx, query, userID).
+		Scan(&p.UserID, &p.FullName, &p.Email, pq.Array(&p.Skills), &p.Location, &p.ProfileCompleteness)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT company, title, start_date, end_date
+        FROM user_employment_history
+        WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var emp domain.EmploymentHistory
+		if err := rows.Scan(&emp.Company, &emp.Title, &emp.StartDate, &emp.EndDate); err != nil {
+			return nil, err
+		}
+		p.EmploymentHistory = append(p.EmploymentHistory, emp)
+	}
+	return p, rows.Err()
+}
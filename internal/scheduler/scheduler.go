@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/zahidhasann88/job-board-api/internal/service"
+	"go.uber.org/zap"
+)
+
+// Scheduler periodically publishes scheduled jobs, closes expired ones,
+// reminds recruiters about upcoming expiries and purges old deleted records.
+type Scheduler struct {
+	jobService  *service.JobService
+	userService *service.UserService
+	logger      *zap.Logger
+	interval    time.Duration
+}
+
+func NewScheduler(jobService *service.JobService, userService *service.UserService, logger *zap.Logger, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		jobService:  jobService,
+		userService: userService,
+		logger:      logger,
+		interval:    interval,
+	}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.tick(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.tick(ctx)
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	now := time.Now()
+
+	n, err := s.jobService.PublishScheduledJobs(ctx, now)
+	if err != nil {
+		s.logger.Error("failed to publish scheduled jobs", zap.Error(err))
+	}
+	if n > 0 {
+		s.logger.Info("published scheduled jobs", zap.Int("count", n))
+	}
+
+	n, err = s.jobService.ExpireJobs(ctx, now)
+	if err != nil {
+		s.logger.Error("failed to expire jobs", zap.Error(err))
+	}
+	if n > 0 {
+		s.logger.Info("closed expired jobs", zap.Int("count", n))
+	}
+
+	n, err = s.jobService.SendExpiryReminders(ctx, now)
+	if err != nil {
+		s.logger.Error("failed to send expiry reminders", zap.Error(err))
+	}
+	if n > 0 {
+		s.logger.Info("sent expiry reminders", zap.Int("count", n))
+	}
+
+	if n, err := s.userService.PurgeDeletedUsers(ctx, now); err != nil {
+		s.logger.Error("failed to purge deleted users", zap.Error(err))
+	} else if n > 0 {
+		s.logger.Info("purged deleted users", zap.Int("count", n))
+	}
+
+	if n, err := s.jobService.PurgeDeletedJobs(ctx, now); err != nil {
+		s.logger.Error("failed to purge deleted jobs", zap.Error(err))
+	} else if n > 0 {
+		s.logger.Info("purged deleted jobs", zap.Int("count", n))
+	}
+}
@@ -0,0 +1,264 @@
+// Package schemaorg renders jobs in the formats search engines index:
+// schema.org JobPosting structured data and sitemaps.
+package schemaorg
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// Builder renders jobs for search engines. SiteURL is the public site whose
+// job pages are indexed, at SiteURL/jobs/<id>, and SiteName its name.
+// Currency is used for salaries that don't name one.
+type Builder struct {
+	SiteName string
+	SiteURL  string
+	Currency string
+}
+
+// JobURL returns the public page of a job.
+func (b *Builder) JobURL(id uuid.UUID) string {
+	return strings.TrimRight(b.SiteURL, "/") + "/jobs/" + id.String()
+}
+
+type JobPosting struct {
+	Context            string          `json:"@context"`
+	Type               string          `json:"@type"`
+	Title              string          `json:"title"`
+	Description        string          `json:"description"`
+	URL                string          `json:"url"`
+	Identifier         PropertyValue   `json:"identifier"`
+	DatePosted         string          `json:"datePosted"`
+	ValidThrough       string          `json:"validThrough,omitempty"`
+	EmploymentType     string          `json:"employmentType"`
+	HiringOrganization Organization    `json:"hiringOrganization"`
+	JobLocation        *Place          `json:"jobLocation,omitempty"`
+	JobLocationType    string          `json:"jobLocationType,omitempty"`
+	BaseSalary         *MonetaryAmount `json:"baseSalary,omitempty"`
+	Skills             string          `json:"skills,omitempty"`
+	TotalJobOpenings   int             `json:"totalJobOpenings,omitempty"`
+	DirectApply        bool            `json:"directApply"`
+}
+
+type PropertyValue struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type Organization struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type Place struct {
+	Type    string        `json:"@type"`
+	Address PostalAddress `json:"address"`
+}
+
+type PostalAddress struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	AddressCountry  string `json:"addressCountry,omitempty"`
+}
+
+type MonetaryAmount struct {
+	Type     string            `json:"@type"`
+	Currency string            `json:"currency"`
+	Value    QuantitativeValue `json:"value"`
+}
+
+type QuantitativeValue struct {
+	Type     string   `json:"@type"`
+	Value    *float64 `json:"value,omitempty"`
+	MinValue *float64 `json:"minValue,omitempty"`
+	MaxValue *float64 `json:"maxValue,omitempty"`
+	UnitText string   `json:"unitText"`
+}
+
+// JobPosting renders a job as a schema.org JobPosting. Company is the
+// recruiter account that posted the job.
+func (b *Builder) JobPosting(job *domain.Job, company *domain.User) *JobPosting {
+	posted := job.CreatedAt
+	if job.PublishedAt != nil {
+		posted = *job.PublishedAt
+	}
+
+	var name string
+	if company != nil {
+		name = company.DisplayName()
+	}
+
+	posting := &JobPosting{
+		Context:     "https://schema.org",
+		Type:        "JobPosting",
+		Title:       job.Title,
+		Description: job.Description,
+		URL:         b.JobURL(job.ID),
+		Identifier: PropertyValue{
+			Type:  "PropertyValue",
+			Name:  name,
+			Value: job.ID.String(),
+		},
+		DatePosted:         posted.UTC().Format(time.RFC3339),
+		EmploymentType:     EmploymentType(job.JobType),
+		HiringOrganization: Organization{Type: "Organization", Name: name},
+		Skills:             strings.Join(job.Skills, ", "),
+		TotalJobOpenings:   job.Openings,
+		DirectApply:        job.ApplyMethod != domain.ApplyMethodExternal,
+	}
+
+	if until := validThrough(job); until != nil {
+		posting.ValidThrough = until.UTC().Format(time.RFC3339)
+	}
+
+	if domain.IsRemoteLocation(job.Location) {
+		posting.JobLocationType = "TELECOMMUTE"
+	} else {
+		posting.JobLocation = place(job.Location)
+	}
+
+	if job.SalaryRange != nil {
+		posting.BaseSalary = b.baseSalary(*job.SalaryRange)
+	}
+
+	return posting
+}
+
+// employmentTypes maps job types, normalized to lower case with
+// underscores, to schema.org employment types.
+var employmentTypes = map[string]string{
+	"full_time":  "FULL_TIME",
+	"fulltime":   "FULL_TIME",
+	"permanent":  "FULL_TIME",
+	"part_time":  "PART_TIME",
+	"parttime":   "PART_TIME",
+	"contract":   "CONTRACTOR",
+	"contractor": "CONTRACTOR",
+	"freelance":  "CONTRACTOR",
+	"temporary":  "TEMPORARY",
+	"temp":       "TEMPORARY",
+	"internship": "INTERN",
+	"intern":     "INTERN",
+	"volunteer":  "VOLUNTEER",
+	"per_diem":   "PER_DIEM",
+}
+
+// EmploymentType maps a job type to a schema.org employment type, or OTHER
+// when there is no match.
+func EmploymentType(jobType string) string {
+	key := strings.ToLower(strings.TrimSpace(jobType))
+	key = strings.NewReplacer("-", "_", " ", "_").Replace(key)
+	if t, ok := employmentTypes[key]; ok {
+		return t
+	}
+	return "OTHER"
+}
+
+// validThrough returns the earlier of the job's expiry and application
+// deadline.
+func validThrough(job *domain.Job) *time.Time {
+	until := job.ExpiresAt
+	if job.ApplicationDeadline != nil && (until == nil || job.ApplicationDeadline.Before(*until)) {
+		until = job.ApplicationDeadline
+	}
+	return until
+}
+
+// place reads a location written as "City", "City, Country" or
+// "City, Region, Country".
+func place(location string) *Place {
+	var parts []string
+	for _, p := range strings.Split(location, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+
+	address := PostalAddress{Type: "PostalAddress", AddressLocality: parts[0]}
+	if len(parts) > 1 {
+		address.AddressCountry = parts[len(parts)-1]
+	}
+	if len(parts) > 2 {
+		address.AddressRegion = strings.Join(parts[1:len(parts)-1], ", ")
+	}
+	return &Place{Type: "Place", Address: address}
+}
+
+var (
+	salaryAmount   = regexp.MustCompile(`(?i)(\d[\d,]*(?:\.\d+)?)\s*(k)?`)
+	salaryCurrency = regexp.MustCompile(`\b[A-Z]{3}\b`)
+)
+
+var currencySymbols = map[string]string{
+	"$": "USD",
+	"€": "EUR",
+	"£": "GBP",
+	"¥": "JPY",
+	"₹": "INR",
+}
+
+// baseSalary reads a free-form salary range such as "50000-70000",
+// "$50k - $70k" or "EUR 30/hour". It returns nil when no amount is found.
+func (b *Builder) baseSalary(salary string) *MonetaryAmount {
+	var amounts []float64
+	for _, m := range salaryAmount.FindAllStringSubmatch(salary, 2) {
+		v, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		if m[2] != "" {
+			v *= 1000
+		}
+		amounts = append(amounts, v)
+	}
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	value := QuantitativeValue{Type: "QuantitativeValue", UnitText: salaryUnit(salary)}
+	if len(amounts) == 1 || amounts[0] == amounts[1] {
+		value.Value = &amounts[0]
+	} else {
+		value.MinValue, value.MaxValue = &amounts[0], &amounts[1]
+	}
+
+	currency := b.Currency
+	if code := salaryCurrency.FindString(salary); code != "" {
+		currency = code
+	} else {
+		for symbol, code := range currencySymbols {
+			if strings.Contains(salary, symbol) {
+				currency = code
+				break
+			}
+		}
+	}
+
+	return &MonetaryAmount{Type: "MonetaryAmount", Currency: currency, Value: value}
+}
+
+func salaryUnit(salary string) string {
+	s := strings.ToLower(salary)
+	switch {
+	case strings.Contains(s, "hour") || strings.Contains(s, "/hr") || strings.Contains(s, "/h"):
+		return "HOUR"
+	case strings.Contains(s, "day"):
+		return "DAY"
+	case strings.Contains(s, "week"):
+		return "WEEK"
+	case strings.Contains(s, "month"):
+		return "MONTH"
+	default:
+		return "YEAR"
+	}
+}
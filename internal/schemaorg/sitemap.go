@@ -0,0 +1,67 @@
+package schemaorg
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// SitemapLimit is the most URLs the sitemap protocol allows in one file.
+const SitemapLimit = 50000
+
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+type sitemapRef struct {
+	Loc string `xml:"loc"`
+}
+
+// WriteSitemap writes a sitemap listing the public pages of jobs.
+func (b *Builder) WriteSitemap(w io.Writer, jobs []domain.Job) error {
+	set := urlSet{XMLNS: sitemapNS, URLs: make([]sitemapURL, len(jobs))}
+	for i, job := range jobs {
+		set.URLs[i] = sitemapURL{
+			Loc:     b.JobURL(job.ID),
+			LastMod: job.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return writeXML(w, set)
+}
+
+// WriteSitemapIndex writes a sitemap index pointing at pages 1 to pages of
+// SiteURL/sitemap.xml, selected with its page query parameter.
+func (b *Builder) WriteSitemapIndex(w io.Writer, pages int) error {
+	index := sitemapIndex{XMLNS: sitemapNS, Sitemaps: make([]sitemapRef, pages)}
+	base := strings.TrimRight(b.SiteURL, "/") + "/sitemap.xml?page="
+	for i := range index.Sitemaps {
+		index.Sitemaps[i].Loc = base + strconv.Itoa(i+1)
+	}
+	return writeXML(w, index)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,148 @@
+package screening
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+)
+
+// Config describes the screening rules and thresholds. It is loaded from a
+// JSON file so rules can be tuned without a deploy.
+type Config struct {
+	ReviewThreshold    float64          `json:"review_threshold"`
+	BlockThreshold     float64          `json:"block_threshold"`
+	Keywords           []KeywordConfig  `json:"keywords"`
+	BlockedDomains     []string         `json:"blocked_domains"`
+	BlockedDomainScore float64          `json:"blocked_domain_score"`
+	Similarity         SimilarityConfig `json:"similarity"`
+	Account            AccountConfig    `json:"account"`
+}
+
+type KeywordConfig struct {
+	Pattern string  `json:"pattern"`
+	Score   float64 `json:"score"`
+	Reason  string  `json:"reason"`
+}
+
+// defaultKnownBadRefresh is how long known-bad postings are cached when the
+// rules file doesn't say.
+const defaultKnownBadRefresh = 5 * time.Minute
+
+type SimilarityConfig struct {
+	Threshold float64  `json:"threshold"`
+	Score     float64  `json:"score"`
+	KnownBad  []string `json:"known_bad"`
+
+	// Minutes known-bad postings are cached before being reloaded
+	RefreshMinutes int `json:"refresh_minutes"`
+}
+
+type AccountConfig struct {
+	MinAgeDays      int     `json:"min_age_days"`
+	NewScore        float64 `json:"new_score"`
+	UnverifiedScore float64 `json:"unverified_score"`
+}
+
+// DefaultConfig returns the built-in rule set used when no rules file is
+// configured.
+func DefaultConfig() *Config {
+	return &Config{
+		ReviewThreshold: 30,
+		BlockThreshold:  70,
+		Keywords: []KeywordConfig{
+			{Pattern: `(?i)\bwire\s+(the\s+)?(money|funds|payment)\b`, Score: 45, Reason: "asks candidates to wire money"},
+			{Pattern: `(?i)\bwestern\s+union\b|\bmoneygram\b`, Score: 40, Reason: "mentions money transfer services"},
+			{Pattern: `(?i)\b(registration|training|application|processing|starter\s+kit)\s+fee\b`, Score: 40, Reason: "charges candidates a fee"},
+			{Pattern: `(?i)\b(bitcoin|btc|crypto(currency)?|usdt|ethereum)\b`, Score: 25, Reason: "mentions cryptocurrency payments"},
+			{Pattern: `(?i)\bgift\s*cards?\b`, Score: 30, Reason: "mentions gift cards"},
+			{Pattern: `(?i)\b(pay|deposit)\s+(upfront|in advance)\b|\bupfront\s+payment\b`, Score: 40, Reason: "asks for upfront payment"},
+			{Pattern: `(?i)\b(telegram|whatsapp)\b`, Score: 15, Reason: "moves contact to a messaging app"},
+			{Pattern: `(?i)\$\s?\d{3,}\s*(per|a|/)\s*(day|hour)\b`, Score: 20, Reason: "promises unusually high pay"},
+		},
+		BlockedDomains:     []string{"bit.ly", "tinyurl.com", "t.me", "wa.me"},
+		BlockedDomainScore: 35,
+		Similarity: SimilarityConfig{
+			Threshold: 0.6,
+			Score:     50,
+		},
+		Account: AccountConfig{
+			MinAgeDays:      7,
+			NewScore:        15,
+			UnverifiedScore: 10,
+		},
+	}
+}
+
+// LoadConfig reads a rules file. An empty path returns DefaultConfig.
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		return DefaultConfig(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read screening rules: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse screening rules: %w", err)
+	}
+	if cfg.BlockThreshold <= 0 || cfg.ReviewThreshold <= 0 || cfg.ReviewThreshold > cfg.BlockThreshold {
+		return nil, fmt.Errorf("screening rules: review_threshold must be positive and not exceed block_threshold")
+	}
+	return cfg, nil
+}
+
+// Build compiles the configured rules into a pipeline. Known-bad texts from
+// the config are combined with those supplied by source, which may be nil.
+func (cfg *Config) Build(source KnownBadSource) (*Pipeline, error) {
+	patterns := make([]Pattern, 0, len(cfg.Keywords))
+	for _, k := range cfg.Keywords {
+		expr, err := regexp.Compile(k.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("screening rules: invalid pattern %q: %w", k.Pattern, err)
+		}
+		patterns = append(patterns, Pattern{Expr: expr, Score: k.Score, Reason: k.Reason})
+	}
+
+	pipeline := NewPipeline(cfg.ReviewThreshold, cfg.BlockThreshold, NewKeywordRule(patterns))
+
+	if len(cfg.BlockedDomains) > 0 {
+		pipeline.Use(NewLinkRule(cfg.BlockedDomains, cfg.BlockedDomainScore))
+	}
+
+	if cfg.Similarity.Threshold > 0 {
+		knownBad := make([]KnownBad, len(cfg.Similarity.KnownBad))
+		for i, text := range cfg.Similarity.KnownBad {
+			knownBad[i] = KnownBad{Text: text}
+		}
+		refresh := time.Duration(cfg.Similarity.RefreshMinutes) * time.Minute
+		if refresh <= 0 {
+			refresh = defaultKnownBadRefresh
+		}
+		pipeline.Use(NewSimilarityRule(func(ctx context.Context) ([]KnownBad, error) {
+			if source == nil {
+				return knownBad, nil
+			}
+			texts, err := source(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return append(texts, knownBad...), nil
+		}, cfg.Similarity.Threshold, cfg.Similarity.Score, refresh))
+	}
+
+	if cfg.Account.MinAgeDays > 0 || cfg.Account.UnverifiedScore > 0 {
+		pipeline.Use(NewAccountAgeRule(
+			time.Duration(cfg.Account.MinAgeDays)*24*time.Hour,
+			cfg.Account.NewScore,
+			cfg.Account.UnverifiedScore,
+		))
+	}
+
+	return pipeline, nil
+}
@@ -0,0 +1,121 @@
+package screening
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"valid", `{"review_threshold": 20, "block_threshold": 60}`, false},
+		{"equal thresholds", `{"review_threshold": 50, "block_threshold": 50}`, false},
+		{"missing thresholds", `{"keywords": []}`, true},
+		{"review above block", `{"review_threshold": 80, "block_threshold": 60}`, true},
+		{"negative review", `{"review_threshold": -1, "block_threshold": 60}`, true},
+		{"malformed", `{"review_threshold": `, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadConfig(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadConfig error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("no path uses defaults", func(t *testing.T) {
+		cfg, err := LoadConfig("")
+		if err != nil {
+			t.Fatalf("LoadConfig: %v", err)
+		}
+		if cfg.ReviewThreshold != DefaultConfig().ReviewThreshold {
+			t.Errorf("ReviewThreshold = %v, want the default", cfg.ReviewThreshold)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+			t.Error("LoadConfig succeeded for a missing file")
+		}
+	})
+}
+
+func TestBuildInvalidPattern(t *testing.T) {
+	cfg := &Config{ReviewThreshold: 30, BlockThreshold: 70, Keywords: []KeywordConfig{{Pattern: "(", Score: 10}}}
+	if _, err := cfg.Build(nil); err == nil {
+		t.Error("Build succeeded with an invalid pattern")
+	}
+}
+
+func TestDefaultPipeline(t *testing.T) {
+	fraudID := uuid.New()
+	source := func(ctx context.Context) ([]KnownBad, error) {
+		return []KnownBad{{JobID: fraudID, Text: "Data entry clerk needed, send your passport scan and bank details to start earning"}}, nil
+	}
+	pipeline, err := DefaultConfig().Build(source)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	established := time.Now().AddDate(-1, 0, 0)
+	tests := []struct {
+		name string
+		in   Input
+		want string
+	}{
+		{
+			"ordinary posting",
+			Input{Title: "Backend Engineer", Description: "Build payment services in Go and Postgres.",
+				PosterCreatedAt: established, PosterVerified: true},
+			DecisionPublish,
+		},
+		{
+			"new unverified poster alone",
+			Input{Title: "Backend Engineer", Description: "Build payment services in Go and Postgres.",
+				PosterCreatedAt: time.Now()},
+			DecisionPublish,
+		},
+		{
+			"one red flag",
+			Input{Title: "Assistant", Description: "Contact us on Telegram via https://t.me/hiring",
+				PosterCreatedAt: established, PosterVerified: true},
+			DecisionReview,
+		},
+		{
+			"textbook scam",
+			Input{Title: "Work from home", Description: "Pay the registration fee upfront via Western Union, then earn $500 per day.",
+				PosterCreatedAt: time.Now()},
+			DecisionBlock,
+		},
+		{
+			"copy of rejected fraud",
+			Input{JobID: uuid.New(), Title: "Data entry clerk", Description: "needed, send your passport scan and bank details to start earning",
+				PosterCreatedAt: established, PosterVerified: true},
+			DecisionReview,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := pipeline.Score(context.Background(), &tt.in)
+			if err != nil {
+				t.Fatalf("Score: %v", err)
+			}
+			if result.Decision != tt.want {
+				t.Errorf("Decision = %s (score %v, %q), want %s", result.Decision, result.Score, result.Reasons, tt.want)
+			}
+		})
+	}
+}
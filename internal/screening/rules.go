@@ -0,0 +1,240 @@
+package screening
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/pkg/textsim"
+)
+
+// Pattern is a weighted regular expression matched against posting text.
+type Pattern struct {
+	Expr   *regexp.Regexp
+	Score  float64
+	Reason string
+}
+
+// KeywordRule flags postings whose title or description match any of its
+// patterns. Each pattern counts once no matter how often it matches.
+type KeywordRule struct {
+	patterns []Pattern
+}
+
+func NewKeywordRule(patterns []Pattern) *KeywordRule {
+	return &KeywordRule{patterns: patterns}
+}
+
+func (r *KeywordRule) Name() string {
+	return "keywords"
+}
+
+func (r *KeywordRule) Evaluate(ctx context.Context, in *Input) ([]Finding, error) {
+	text := in.Title + "\n" + in.Description
+	if in.SalaryRange != nil {
+		text += "\n" + *in.SalaryRange
+	}
+
+	var findings []Finding
+	for _, p := range r.patterns {
+		if match := p.Expr.FindString(text); match != "" {
+			reason := p.Reason
+			if reason == "" {
+				reason = fmt.Sprintf("matched %q", match)
+			}
+			findings = append(findings, Finding{Rule: r.Name(), Score: p.Score, Reason: reason})
+		}
+	}
+	return findings, nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"')]+`)
+
+// LinkRule flags postings that link to blocklisted domains, including their
+// subdomains.
+type LinkRule struct {
+	blockedDomains map[string]struct{}
+	score          float64
+}
+
+func NewLinkRule(blockedDomains []string, score float64) *LinkRule {
+	domains := make(map[string]struct{}, len(blockedDomains))
+	for _, d := range blockedDomains {
+		domains[strings.ToLower(strings.TrimPrefix(d, "."))] = struct{}{}
+	}
+	return &LinkRule{blockedDomains: domains, score: score}
+}
+
+func (r *LinkRule) Name() string {
+	return "links"
+}
+
+func (r *LinkRule) Evaluate(ctx context.Context, in *Input) ([]Finding, error) {
+	seen := make(map[string]bool)
+	var findings []Finding
+	for _, link := range linkPattern.FindAllString(in.Description, -1) {
+		host := linkHost(link)
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		if domain, ok := r.blockedDomain(host); ok {
+			findings = append(findings, Finding{
+				Rule:   r.Name(),
+				Score:  r.score,
+				Reason: fmt.Sprintf("links to blocked domain %s", domain),
+			})
+		}
+	}
+	return findings, nil
+}
+
+func (r *LinkRule) blockedDomain(host string) (string, bool) {
+	for {
+		if _, ok := r.blockedDomains[host]; ok {
+			return host, true
+		}
+		i := strings.IndexByte(host, '.')
+		if i < 0 {
+			return "", false
+		}
+		host = host[i+1:]
+	}
+}
+
+func linkHost(link string) string {
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+	u, err := url.Parse(strings.TrimRight(link, ".,;:!?"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// KnownBad is the text of a posting identified as fraud. JobID is zero for
+// texts that don't belong to a posting, such as those in the rules file.
+type KnownBad struct {
+	JobID uuid.UUID
+	Text  string
+}
+
+// KnownBadSource supplies the postings already identified as fraud.
+type KnownBadSource func(ctx context.Context) ([]KnownBad, error)
+
+type shingledText struct {
+	jobID    uuid.UUID
+	shingles map[string]struct{}
+}
+
+// SimilarityRule flags postings whose text closely matches a known-bad post.
+// The known-bad postings are shingled once and reloaded from the source when
+// older than refresh, so new fraud takes up to refresh to be picked up.
+type SimilarityRule struct {
+	source    KnownBadSource
+	threshold float64
+	score     float64
+	refresh   time.Duration
+
+	mu       sync.Mutex
+	corpus   []shingledText
+	loadedAt time.Time
+}
+
+func NewSimilarityRule(source KnownBadSource, threshold, score float64, refresh time.Duration) *SimilarityRule {
+	return &SimilarityRule{source: source, threshold: threshold, score: score, refresh: refresh}
+}
+
+func (r *SimilarityRule) Name() string {
+	return "similarity"
+}
+
+func (r *SimilarityRule) Evaluate(ctx context.Context, in *Input) ([]Finding, error) {
+	corpus, err := r.knownBad(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shingles := textsim.Shingles(in.Title+" "+in.Description, 3)
+	best := 0.0
+	for _, known := range corpus {
+		// A rejected posting that is edited and resubmitted isn't its own match
+		if known.jobID != uuid.Nil && known.jobID == in.JobID {
+			continue
+		}
+		if sim := textsim.Jaccard(shingles, known.shingles); sim > best {
+			best = sim
+		}
+	}
+	if best < r.threshold {
+		return nil, nil
+	}
+
+	return []Finding{{
+		Rule:   r.Name(),
+		Score:  r.score,
+		Reason: fmt.Sprintf("%.0f%% similar to a known fraudulent posting", best*100),
+	}}, nil
+}
+
+// knownBad returns the shingled known-bad postings, reloading them from the
+// source once they are older than the refresh interval.
+func (r *SimilarityRule) knownBad(ctx context.Context) ([]shingledText, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.corpus != nil && time.Since(r.loadedAt) < r.refresh {
+		return r.corpus, nil
+	}
+
+	texts, err := r.source(ctx)
+	if err != nil {
+		return nil, err
+	}
+	corpus := make([]shingledText, len(texts))
+	for i, text := range texts {
+		corpus[i] = shingledText{jobID: text.JobID, shingles: textsim.Shingles(text.Text, 3)}
+	}
+	r.corpus, r.loadedAt = corpus, time.Now()
+	return corpus, nil
+}
+
+// AccountAgeRule flags postings from recently created or unverified accounts.
+type AccountAgeRule struct {
+	minAge          time.Duration
+	newScore        float64
+	unverifiedScore float64
+}
+
+func NewAccountAgeRule(minAge time.Duration, newScore, unverifiedScore float64) *AccountAgeRule {
+	return &AccountAgeRule{minAge: minAge, newScore: newScore, unverifiedScore: unverifiedScore}
+}
+
+func (r *AccountAgeRule) Name() string {
+	return "account"
+}
+
+func (r *AccountAgeRule) Evaluate(ctx context.Context, in *Input) ([]Finding, error) {
+	var findings []Finding
+	if age := time.Since(in.PosterCreatedAt); age < r.minAge {
+		findings = append(findings, Finding{
+			Rule:   r.Name(),
+			Score:  r.newScore,
+			Reason: fmt.Sprintf("poster account is only %d day(s) old", int(age.Hours()/24)),
+		})
+	}
+	if !in.PosterVerified {
+		findings = append(findings, Finding{
+			Rule:   r.Name(),
+			Score:  r.unverifiedScore,
+			Reason: "poster account is not verified",
+		})
+	}
+	return findings, nil
+}
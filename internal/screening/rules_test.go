@@ -0,0 +1,212 @@
+package screening
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func scores(findings []Finding) []float64 {
+	out := make([]float64, len(findings))
+	for i, f := range findings {
+		out[i] = f.Score
+	}
+	return out
+}
+
+func total(findings []Finding) float64 {
+	var sum float64
+	for _, f := range findings {
+		sum += f.Score
+	}
+	return sum
+}
+
+func TestKeywordRule(t *testing.T) {
+	rule := NewKeywordRule([]Pattern{
+		{Expr: regexp.MustCompile(`(?i)\bwire\s+money\b`), Score: 45, Reason: "asks candidates to wire money"},
+		{Expr: regexp.MustCompile(`(?i)\bgift\s*cards?\b`), Score: 30},
+	})
+	salary := "Paid in gift cards"
+
+	tests := []struct {
+		name string
+		in   Input
+		want float64
+	}{
+		{"clean", Input{Title: "Engineer", Description: "Build things"}, 0},
+		{"in title", Input{Title: "Wire money agent"}, 45},
+		{"in description", Input{Description: "You will WIRE  MONEY daily"}, 45},
+		{"in salary", Input{Description: "Great job", SalaryRange: &salary}, 30},
+		{"counted once", Input{Description: "gift card, gift cards and more gift cards"}, 30},
+		{"several patterns", Input{Title: "Gift cards", Description: "then wire money"}, 75},
+		{"word boundaries", Input{Description: "hardwire moneybox"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := rule.Evaluate(context.Background(), &tt.in)
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if got := total(findings); got != tt.want {
+				t.Errorf("score = %v, want %v (%v)", got, tt.want, findings)
+			}
+		})
+	}
+
+	t.Run("reason defaults to the match", func(t *testing.T) {
+		findings, _ := rule.Evaluate(context.Background(), &Input{Description: "Gift Card bonus"})
+		if len(findings) != 1 || findings[0].Reason != `matched "Gift Card"` {
+			t.Errorf("findings = %+v", findings)
+		}
+	})
+}
+
+func TestLinkRule(t *testing.T) {
+	rule := NewLinkRule([]string{"bit.ly", ".t.me", "Example.COM"}, 35)
+
+	tests := []struct {
+		name        string
+		description string
+		want        []float64
+	}{
+		{"no links", "Apply on our site", []float64{}},
+		{"allowed link", "See https://careers.acme.io/jobs/1", []float64{}},
+		{"blocked link", "Apply at https://bit.ly/abc", []float64{35}},
+		{"www link", "Go to www.bit.ly/abc.", []float64{35}},
+		{"subdomain", "Chat on http://join.t.me/x", []float64{35}},
+		{"case-insensitive", "HTTPS://EXAMPLE.com/path", []float64{35}},
+		{"lookalike domain", "https://notbit.ly/abc and https://bit.ly.evil.io", []float64{}},
+		{"same host once", "https://bit.ly/a https://bit.ly/b", []float64{35}},
+		{"each domain counts", "https://bit.ly/a then https://t.me/b", []float64{35, 35}},
+		{"trailing punctuation", "Apply: https://bit.ly/abc!", []float64{35}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := rule.Evaluate(context.Background(), &Input{Description: tt.description})
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if got := scores(findings); !slices.Equal(got, tt.want) {
+				t.Errorf("findings = %+v, want scores %v", findings, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimilarityRule(t *testing.T) {
+	scam := "Earn five thousand dollars a week from home, just pay the starter kit fee by wire transfer today"
+	scamJob := uuid.New()
+	source := func(ctx context.Context) ([]KnownBad, error) {
+		return []KnownBad{{JobID: scamJob, Text: scam}, {Text: "Send us your bank login to receive your first salary"}}, nil
+	}
+	rule := NewSimilarityRule(source, 0.6, 50, time.Minute)
+
+	tests := []struct {
+		name string
+		in   Input
+		want float64
+	}{
+		{"unrelated", Input{JobID: uuid.New(), Title: "Engineer", Description: "Build payment services in Go"}, 0},
+		{"copy of known bad", Input{JobID: uuid.New(), Description: scam}, 50},
+		{"reformatted copy", Input{JobID: uuid.New(), Title: "EARN", Description: "five thousand dollars a week from home! Just pay the starter-kit fee, by wire transfer, today."}, 50},
+		{"config text", Input{JobID: uuid.New(), Description: "Send us your bank login to receive your first salary"}, 50},
+		{"the rejected job itself", Input{JobID: scamJob, Description: scam}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := rule.Evaluate(context.Background(), &tt.in)
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if got := total(findings); got != tt.want {
+				t.Errorf("score = %v, want %v (%v)", got, tt.want, findings)
+			}
+		})
+	}
+}
+
+func TestSimilarityRuleCachesCorpus(t *testing.T) {
+	scam := "Earn five thousand dollars a week from home, just pay the starter kit fee by wire transfer today"
+	loads := 0
+	var known []KnownBad
+	source := func(ctx context.Context) ([]KnownBad, error) {
+		loads++
+		return known, nil
+	}
+	rule := NewSimilarityRule(source, 0.6, 50, time.Hour)
+	in := &Input{JobID: uuid.New(), Description: scam}
+
+	for i := 0; i < 3; i++ {
+		if _, err := rule.Evaluate(context.Background(), in); err != nil {
+			t.Fatalf("Evaluate: %v", err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("source loaded %d times, want once", loads)
+	}
+
+	// New fraud shows up once the cache is older than the refresh interval
+	known = []KnownBad{{JobID: uuid.New(), Text: scam}}
+	if findings, _ := rule.Evaluate(context.Background(), in); len(findings) != 0 {
+		t.Errorf("findings before refresh = %+v, want none", findings)
+	}
+	rule.loadedAt = time.Now().Add(-2 * time.Hour)
+	findings, err := rule.Evaluate(context.Background(), in)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if loads != 2 || len(findings) != 1 {
+		t.Errorf("after refresh: %d loads, findings %+v; want 2 loads and one finding", loads, findings)
+	}
+}
+
+func TestSimilarityRuleSourceError(t *testing.T) {
+	boom := errors.New("boom")
+	calls := 0
+	rule := NewSimilarityRule(func(ctx context.Context) ([]KnownBad, error) {
+		calls++
+		return nil, boom
+	}, 0.6, 50, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, err := rule.Evaluate(context.Background(), &Input{}); !errors.Is(err, boom) {
+			t.Fatalf("Evaluate error = %v, want %v", err, boom)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("source called %d times, want a retry after the failure", calls)
+	}
+}
+
+func TestAccountAgeRule(t *testing.T) {
+	rule := NewAccountAgeRule(7*24*time.Hour, 15, 10)
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		in   Input
+		want float64
+	}{
+		{"established and verified", Input{PosterCreatedAt: now.AddDate(0, -1, 0), PosterVerified: true}, 0},
+		{"new", Input{PosterCreatedAt: now.AddDate(0, 0, -2), PosterVerified: true}, 15},
+		{"unverified", Input{PosterCreatedAt: now.AddDate(-1, 0, 0)}, 10},
+		{"new and unverified", Input{PosterCreatedAt: now}, 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := rule.Evaluate(context.Background(), &tt.in)
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if got := total(findings); got != tt.want {
+				t.Errorf("score = %v, want %v (%v)", got, tt.want, findings)
+			}
+		})
+	}
+}
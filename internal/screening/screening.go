@@ -0,0 +1,111 @@
+package screening
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Decisions returned by the pipeline
+const (
+	DecisionPublish = "publish"
+	DecisionReview  = "review"
+	DecisionBlock   = "block"
+)
+
+// Input is the content and poster information a posting is screened on.
+type Input struct {
+	JobID           uuid.UUID
+	Title           string
+	Description     string
+	Location        string
+	SalaryRange     *string
+	Skills          []string
+	PosterID        uuid.UUID
+	PosterCreatedAt time.Time
+	PosterVerified  bool
+}
+
+// Finding is a single rule hit contributing to the overall score.
+type Finding struct {
+	Rule   string
+	Score  float64
+	Reason string
+}
+
+// Result is the outcome of screening a posting.
+type Result struct {
+	Score    float64  `json:"score"`
+	Reasons  []string `json:"reasons"`
+	Decision string   `json:"decision"`
+}
+
+// Rule scores one aspect of a posting. Rules return no findings when the
+// posting looks clean.
+type Rule interface {
+	Name() string
+	Evaluate(ctx context.Context, in *Input) ([]Finding, error)
+}
+
+// Pipeline runs every rule over a posting, sums their scores and maps the
+// total onto a decision using the configured thresholds.
+type Pipeline struct {
+	rules           []Rule
+	reviewThreshold float64
+	blockThreshold  float64
+}
+
+func NewPipeline(reviewThreshold, blockThreshold float64, rules ...Rule) *Pipeline {
+	return &Pipeline{
+		rules:           rules,
+		reviewThreshold: reviewThreshold,
+		blockThreshold:  blockThreshold,
+	}
+}
+
+// Use appends rules to the pipeline.
+func (p *Pipeline) Use(rules ...Rule) {
+	p.rules = append(p.rules, rules...)
+}
+
+// NeedsReview reports whether a posting with the given score has to be
+// reviewed before it goes live. Scores high enough to block count too.
+func (p *Pipeline) NeedsReview(score float64) bool {
+	return score >= p.reviewThreshold
+}
+
+func (p *Pipeline) Score(ctx context.Context, in *Input) (*Result, error) {
+	var findings []Finding
+	for _, rule := range p.rules {
+		ruleFindings, err := rule.Evaluate(ctx, in)
+		if err != nil {
+			return nil, fmt.Errorf("screening rule %s: %w", rule.Name(), err)
+		}
+		findings = append(findings, ruleFindings...)
+	}
+
+	// Highest-scoring reasons first
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Score > findings[j].Score
+	})
+
+	result := &Result{Reasons: []string{}}
+	for _, f := range findings {
+		result.Score += f.Score
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%s: %s", f.Rule, f.Reason))
+	}
+
+	switch {
+	case result.Score >= p.blockThreshold:
+		result.Decision = DecisionBlock
+	case result.Score >= p.reviewThreshold:
+		result.Decision = DecisionReview
+	default:
+		result.Decision = DecisionPublish
+	}
+
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package screening
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// fixedRule returns the same findings, or error, for every posting.
+type fixedRule struct {
+	name     string
+	findings []Finding
+	err      error
+}
+
+func (r *fixedRule) Name() string { return r.name }
+
+func (r *fixedRule) Evaluate(ctx context.Context, in *Input) ([]Finding, error) {
+	return r.findings, r.err
+}
+
+func finding(rule string, score float64, reason string) Finding {
+	return Finding{Rule: rule, Score: score, Reason: reason}
+}
+
+func TestPipelineScore(t *testing.T) {
+	tests := []struct {
+		name         string
+		rules        []Rule
+		wantScore    float64
+		wantDecision string
+		wantReasons  []string
+	}{
+		{"no rules", nil, 0, DecisionPublish, []string{}},
+		{"clean posting", []Rule{&fixedRule{name: "a"}}, 0, DecisionPublish, []string{}},
+		{
+			"below review",
+			[]Rule{&fixedRule{name: "a", findings: []Finding{finding("a", 29, "small")}}},
+			29, DecisionPublish, []string{"a: small"},
+		},
+		{
+			"at review threshold",
+			[]Rule{&fixedRule{name: "a", findings: []Finding{finding("a", 30, "odd")}}},
+			30, DecisionReview, []string{"a: odd"},
+		},
+		{
+			"scores add up across rules",
+			[]Rule{
+				&fixedRule{name: "a", findings: []Finding{finding("a", 20, "one")}},
+				&fixedRule{name: "b", findings: []Finding{finding("b", 25, "two")}},
+			},
+			45, DecisionReview, []string{"b: two", "a: one"},
+		},
+		{
+			"at block threshold",
+			[]Rule{
+				&fixedRule{name: "a", findings: []Finding{finding("a", 40, "one"), finding("a", 10, "two")}},
+				&fixedRule{name: "b", findings: []Finding{finding("b", 20, "three")}},
+			},
+			70, DecisionBlock, []string{"a: one", "b: three", "a: two"},
+		},
+		{
+			"equal scores keep rule order",
+			[]Rule{
+				&fixedRule{name: "a", findings: []Finding{finding("a", 10, "first")}},
+				&fixedRule{name: "b", findings: []Finding{finding("b", 10, "second")}},
+			},
+			20, DecisionPublish, []string{"a: first", "b: second"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPipeline(30, 70, tt.rules...)
+			result, err := p.Score(context.Background(), &Input{})
+			if err != nil {
+				t.Fatalf("Score: %v", err)
+			}
+			if result.Score != tt.wantScore || result.Decision != tt.wantDecision {
+				t.Errorf("Score = %v %s, want %v %s", result.Score, result.Decision, tt.wantScore, tt.wantDecision)
+			}
+			if !slices.Equal(result.Reasons, tt.wantReasons) {
+				t.Errorf("Reasons = %q, want %q", result.Reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestPipelineRuleError(t *testing.T) {
+	boom := errors.New("boom")
+	p := NewPipeline(30, 70, &fixedRule{name: "broken", err: boom})
+	if _, err := p.Score(context.Background(), &Input{}); !errors.Is(err, boom) {
+		t.Errorf("Score error = %v, want %v", err, boom)
+	}
+}
+
+func TestPipelineUse(t *testing.T) {
+	p := NewPipeline(30, 70)
+	p.Use(&fixedRule{name: "a", findings: []Finding{finding("a", 35, "added later")}})
+	result, err := p.Score(context.Background(), &Input{})
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	if result.Decision != DecisionReview {
+		t.Errorf("Decision = %s, want %s", result.Decision, DecisionReview)
+	}
+}
+
+func TestPipelineNeedsReview(t *testing.T) {
+	p := NewPipeline(30, 70)
+	tests := []struct {
+		score float64
+		want  bool
+	}{
+		{0, false},
+		{29.9, false},
+		{30, true},
+		{69, true},
+		{70, true},
+		{150, true},
+	}
+	for _, tt := range tests {
+		if got := p.NeedsReview(tt.score); got != tt.want {
+			t.Errorf("NeedsReview(%v) = %v, want %v", tt.score, got, tt.want)
+		}
+	}
+}
@@ -1,26 +1,177 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/zahidhasann88/job-board-api/internal/domain"
 	"github.com/zahidhasann88/job-board-api/internal/repository/postgres"
 )
 
+var (
+	ErrJobNotOpen          = errors.New("job is not accepting applications")
+	ErrDeadlinePassed      = errors.New("the application deadline for this job has passed")
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrNotJobOwner         = errors.New("not allowed to manage applications for this job")
+	ErrExternalApplication = errors.New("this job takes applications on the company's own site")
+)
+
+// PositionFilledNotice is the data available to the template sent to
+// applicants who are rejected automatically once a job is filled.
+type PositionFilledNotice struct {
+	JobID    uuid.UUID
+	JobTitle string
+}
+
 type ApplicationService struct {
-	applicationRepo *postgres.ApplicationRepository
+	applicationRepo     *postgres.ApplicationRepository
+	jobService          *JobService
+	notificationService *NotificationService
+	filledNotice        *template.Template
 }
 
-func NewApplicationService(applicationRepo *postgres.ApplicationRepository) *ApplicationService {
-	return &ApplicationService{applicationRepo: applicationRepo}
+func NewApplicationService(
+	applicationRepo *postgres.ApplicationRepository,
+	jobService *JobService,
+	notificationService *NotificationService,
+	filledNotice *template.Template,
+) *ApplicationService {
+	return &ApplicationService{
+		applicationRepo:     applicationRepo,
+		jobService:          jobService,
+		notificationService: notificationService,
+		filledNotice:        filledNotice,
+	}
 }
 
 func (s *ApplicationService) Create(ctx context.Context, application *domain.Application) error {
+	job, err := s.jobService.GetJob(ctx, application.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return ErrJobNotFound
+	}
+	if job.Status != domain.JobStatusActive {
+		return ErrJobNotOpen
+	}
+	if job.ApplyMethod == domain.ApplyMethodExternal {
+		return ErrExternalApplication
+	}
+	now := time.Now()
+	if job.ApplicationDeadline != nil && now.After(*job.ApplicationDeadline) {
+		return ErrDeadlinePassed
+	}
+	if job.ExpiresAt != nil && now.After(*job.ExpiresAt) {
+		return ErrJobNotOpen
+	}
+
+	application.Source = strings.ToLower(strings.TrimSpace(application.Source))
+	if application.Source == "" {
+		application.Source = domain.ApplicationSourceDirect
+	}
+
 	application.ID = uuid.New()
 	return s.applicationRepo.Create(ctx, application)
 }
 
 func (s *ApplicationService) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Application, error) {
 	return s.applicationRepo.ListByUser(ctx, userID)
-}
\ No newline at end of file
+}
+
+// ChangeStatus moves an application through the hiring pipeline on behalf of
+// the recruiter who owns the job. Accepting an application may fill the job.
+func (s *ApplicationService) ChangeStatus(ctx context.Context, id, recruiterID uuid.UUID, status string) (*domain.Application, error) {
+	application, err := s.applicationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, ErrApplicationNotFound
+	}
+
+	job, err := s.jobService.GetJob(ctx, application.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrJobNotFound
+	}
+	if job.CompanyID != recruiterID {
+		return nil, ErrNotJobOwner
+	}
+
+	if application.Status == status {
+		return application, nil
+	}
+	if err := s.applicationRepo.UpdateStatus(ctx, id, status, &recruiterID); err != nil {
+		return nil, err
+	}
+	application.Status = status
+	application.UpdatedAt = time.Now()
+
+	if status == domain.ApplicationStatusAccepted {
+		if err := s.closeIfFilled(ctx, job, recruiterID); err != nil {
+			return application, err
+		}
+	}
+
+	return application, nil
+}
+
+// closeIfFilled closes a job once it has as many accepted applications as
+// openings and, if the job asks for it, rejects the applications still open.
+// Jobs that are already closed or can't be closed from their current status,
+// such as rejected ones, are left alone.
+func (s *ApplicationService) closeIfFilled(ctx context.Context, job *domain.Job, recruiterID uuid.UUID) error {
+	if !contains(jobStatusTransitions[job.Status], domain.JobStatusClosed) {
+		return nil
+	}
+
+	accepted, err := s.applicationRepo.CountByStatus(ctx, job.ID, domain.ApplicationStatusAccepted)
+	if err != nil {
+		return err
+	}
+	if accepted < job.Openings {
+		return nil
+	}
+
+	if err := s.jobService.ChangeJobStatus(ctx, job.ID, domain.JobStatusClosed, &recruiterID); err != nil {
+		return err
+	}
+	err = s.notificationService.Notify(ctx, job.CompanyID, domain.NotificationJobFilled,
+		"Job filled",
+		fmt.Sprintf("All %d position(s) for \"%s\" have been filled and the job was closed.", job.Openings, job.Title),
+		&job.ID)
+	if err != nil {
+		return err
+	}
+
+	if !job.AutoRejectRemaining {
+		return nil
+	}
+
+	rejected, err := s.applicationRepo.RejectOpen(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+
+	var message bytes.Buffer
+	if err := s.filledNotice.Execute(&message, PositionFilledNotice{JobID: job.ID, JobTitle: job.Title}); err != nil {
+		return err
+	}
+	for _, application := range rejected {
+		err := s.notificationService.Notify(ctx, application.ApplicantID, domain.NotificationApplicationStatus,
+			"Application update", message.String(), &job.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+var (
+	ErrInvalidInterval = errors.New("interval must be day, week or month")
+	ErrInvalidGroupBy  = errors.New("group_by must be job, location or job_type")
+)
+
+// pipelineStages are the stages of stage conversion, in order. An application
+// counts as having reached a stage when it has been in it or any later one.
+var pipelineStages = []string{
+	domain.ApplicationStatusPending,
+	domain.ApplicationStatusReviewed,
+	domain.ApplicationStatusInterviewed,
+	domain.ApplicationStatusAccepted,
+}
+
+// Dashboard reports the company's hiring pipeline between q.From and q.To,
+// both inclusive, next to the period of the same length just before it.
+func (s *ApplicationService) Dashboard(ctx context.Context, companyID uuid.UUID, q domain.DashboardQuery) (*domain.ApplicationDashboard, error) {
+	switch q.Interval {
+	case "":
+		q.Interval = domain.IntervalDay
+	case domain.IntervalDay, domain.IntervalWeek, domain.IntervalMonth:
+	default:
+		return nil, ErrInvalidInterval
+	}
+	switch q.GroupBy {
+	case "", domain.GroupByJob, domain.GroupByLocation, domain.GroupByJobType:
+	default:
+		return nil, ErrInvalidGroupBy
+	}
+
+	from, to := dayOf(q.From), dayOf(q.To)
+	if to.Before(from) || to.Sub(from) >= maxReportDays*24*time.Hour {
+		return nil, ErrInvalidDateRange
+	}
+	end := to.AddDate(0, 0, 1)
+	days := int(end.Sub(from) / (24 * time.Hour))
+	prevFrom := from.AddDate(0, 0, -days)
+
+	activity, err := s.applicationRepo.ListActivity(ctx, companyID, prevFrom, end)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &domain.ApplicationDashboard{
+		From:         from.Format(time.DateOnly),
+		To:           to.Format(time.DateOnly),
+		PreviousFrom: prevFrom.Format(time.DateOnly),
+		PreviousTo:   from.AddDate(0, 0, -1).Format(time.DateOnly),
+		Interval:     q.Interval,
+		GroupBy:      q.GroupBy,
+		Current:      applicationMetrics(activity, from, end),
+		Previous:     applicationMetrics(activity, prevFrom, from),
+	}
+	dashboard.Change = metricsChange(dashboard.Current, dashboard.Previous)
+
+	if q.GroupBy == "" {
+		dashboard.Series = dashboardSeries(activity, from, end, q.Interval, "", "")
+		return dashboard, nil
+	}
+
+	groups := make(map[string][]domain.ApplicationActivity)
+	labels := make(map[string]string)
+	for _, a := range activity {
+		key, label := activityGroup(a, q.GroupBy)
+		groups[key] = append(groups[key], a)
+		labels[key] = label
+	}
+	for key, members := range groups {
+		group := domain.DashboardGroup{
+			Key:      key,
+			Label:    labels[key],
+			Current:  applicationMetrics(members, from, end),
+			Previous: applicationMetrics(members, prevFrom, from),
+		}
+		group.Change = metricsChange(group.Current, group.Previous)
+		dashboard.Groups = append(dashboard.Groups, group)
+	}
+	sort.Slice(dashboard.Groups, func(i, j int) bool {
+		if dashboard.Groups[i].Label != dashboard.Groups[j].Label {
+			return dashboard.Groups[i].Label < dashboard.Groups[j].Label
+		}
+		return dashboard.Groups[i].Key < dashboard.Groups[j].Key
+	})
+	for _, group := range dashboard.Groups {
+		points := dashboardSeries(groups[group.Key], from, end, q.Interval, group.Key, group.Label)
+		dashboard.Series = append(dashboard.Series, points...)
+	}
+	return dashboard, nil
+}
+
+func activityGroup(a domain.ApplicationActivity, groupBy string) (key, label string) {
+	switch groupBy {
+	case domain.GroupByJob:
+		return a.JobID.String(), a.JobTitle
+	case domain.GroupByLocation:
+		return a.Location, a.Location
+	default:
+		return a.JobType, a.JobType
+	}
+}
+
+// applicationMetrics summarizes the applications submitted and the hires made
+// in [start, end).
+func applicationMetrics(activity []domain.ApplicationActivity, start, end time.Time) domain.ApplicationMetrics {
+	m := domain.ApplicationMetrics{Sources: make(map[string]int)}
+	var reviewHours, hireDays []float64
+	reached := make([]int, len(pipelineStages))
+
+	for _, a := range activity {
+		if within(a.HiredAt, start, end) {
+			m.Hires++
+			hireDays = append(hireDays, a.HiredAt.Sub(a.CreatedAt).Hours()/24)
+		}
+		if !within(&a.CreatedAt, start, end) {
+			continue
+		}
+		m.Applications++
+		m.Sources[a.Source]++
+		if a.FirstReviewAt != nil {
+			reviewHours = append(reviewHours, a.FirstReviewAt.Sub(a.CreatedAt).Hours())
+		}
+		furthest := 0
+		for i, stage := range pipelineStages {
+			if slices.Contains(a.Statuses, stage) {
+				furthest = i
+			}
+		}
+		for i := 0; i <= furthest; i++ {
+			reached[i]++
+		}
+	}
+
+	m.TimeToFirstReview = durationStats(reviewHours)
+	m.TimeToHire = durationStats(hireDays)
+	for i, stage := range pipelineStages {
+		conversion := domain.StageConversion{Stage: stage, Applications: reached[i], Rate: 1}
+		if i > 0 {
+			conversion.Rate = ratio(reached[i], reached[i-1])
+		}
+		m.Stages = append(m.Stages, conversion)
+	}
+	return m
+}
+
+// dashboardSeries counts applications and hires per interval in [start, end).
+func dashboardSeries(activity []domain.ApplicationActivity, start, end time.Time, interval, group, label string) []domain.DashboardPoint {
+	var points []domain.DashboardPoint
+	index := make(map[time.Time]int)
+	for p := periodStart(start, interval); p.Before(end); p = nextPeriod(p, interval) {
+		index[p] = len(points)
+		points = append(points, domain.DashboardPoint{
+			Period: p.Format(time.DateOnly),
+			Group:  group,
+			Label:  label,
+		})
+	}
+
+	for _, a := range activity {
+		if within(&a.CreatedAt, start, end) {
+			points[index[periodStart(a.CreatedAt, interval)]].Applications++
+		}
+		if within(a.HiredAt, start, end) {
+			points[index[periodStart(*a.HiredAt, interval)]].Hires++
+		}
+	}
+	return points
+}
+
+// periodStart returns the UTC start of the interval containing t. Weeks start
+// on Monday.
+func periodStart(t time.Time, interval string) time.Time {
+	day := dayOf(t)
+	switch interval {
+	case domain.IntervalWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case domain.IntervalMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+func nextPeriod(t time.Time, interval string) time.Time {
+	switch interval {
+	case domain.IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case domain.IntervalMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+func within(t *time.Time, start, end time.Time) bool {
+	return t != nil && !t.Before(start) && t.Before(end)
+}
+
+func durationStats(values []float64) domain.DurationStats {
+	stats := domain.DurationStats{Count: len(values)}
+	if len(values) == 0 {
+		return stats
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	stats.Average = sum / float64(len(sorted))
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		stats.Median = (sorted[mid-1] + sorted[mid]) / 2
+	} else {
+		stats.Median = sorted[mid]
+	}
+	return stats
+}
+
+func metricsChange(current, previous domain.ApplicationMetrics) domain.MetricsChange {
+	return domain.MetricsChange{
+		Applications:      relativeChange(float64(current.Applications), float64(previous.Applications)),
+		Hires:             relativeChange(float64(current.Hires), float64(previous.Hires)),
+		TimeToFirstReview: relativeChange(current.TimeToFirstReview.Average, previous.TimeToFirstReview.Average),
+		TimeToHire:        relativeChange(current.TimeToHire.Average, previous.TimeToHire.Average),
+	}
+}
+
+func relativeChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := (current - previous) / previous
+	return &change
+}
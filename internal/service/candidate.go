@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+var ErrInvalidExperienceRange = errors.New("min_years must not exceed max_years")
+
+// SearchCandidates finds job seekers whose profiles the company may see, most
+// relevant to the query first.
+func (s *UserService) SearchCandidates(ctx context.Context, companyID uuid.UUID, search domain.CandidateSearch, page, pageSize int) ([]domain.CandidateSummary, int, error) {
+	if search.MinYears != nil && search.MaxYears != nil && *search.MinYears > *search.MaxYears {
+		return nil, 0, ErrInvalidExperienceRange
+	}
+	search.Skills = s.taxonomy.Normalize(search.Skills)
+
+	candidates, total, err := s.userRepo.SearchCandidates(ctx, companyID, search, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range candidates {
+		for _, emp := range candidates[i].EmploymentHistory {
+			if emp.EndDate == nil {
+				candidates[i].CurrentTitle = &emp.Title
+				break
+			}
+		}
+	}
+	return candidates, total, nil
+}
+
+// ViewCandidate returns the profile of a job seeker found in search, as the
+// company may see it, and counts the view.
+func (s *UserService) ViewCandidate(ctx context.Context, companyID uuid.UUID, visitor string, id uuid.UUID) (*domain.User, error) {
+	profile, err := s.viewProfile(ctx, &companyID, domain.RoleRecruiter, id)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Role != domain.RoleJobSeeker {
+		return nil, ErrUserNotFound
+	}
+
+	if err := s.recordProfileView(ctx, &companyID, domain.RoleRecruiter, visitor, id); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/pkg/textsim"
+)
+
+// Duplicate posting policies
+const (
+	DuplicatePolicyOff    = "off"
+	DuplicatePolicyWarn   = "warn"
+	DuplicatePolicyReject = "reject"
+)
+
+const (
+	minHashSize  = 128
+	minHashBands = 32
+)
+
+var ErrDuplicateJob = errors.New("job duplicates an existing posting")
+
+// DuplicateJobError carries the IDs of the postings a new job duplicates.
+type DuplicateJobError struct {
+	MatchIDs []uuid.UUID
+}
+
+func (e *DuplicateJobError) Error() string {
+	return fmt.Sprintf("%s (%d matching job(s))", ErrDuplicateJob, len(e.MatchIDs))
+}
+
+func (e *DuplicateJobError) Unwrap() error {
+	return ErrDuplicateJob
+}
+
+type jobSignature struct {
+	job *domain.Job
+	sig []uint64
+}
+
+func signJob(job *domain.Job) jobSignature {
+	text := job.Title + " " + job.Description + " " + job.Location
+	return jobSignature{job: job, sig: textsim.MinHash(textsim.Shingles(text, 3), minHashSize)}
+}
+
+// checkDuplicates compares a posting against others from the same company
+// and applies the duplicate policy: under "warn" the matches are recorded on
+// the job, under "reject" a DuplicateJobError is returned. Drafts, such as
+// clones that start out as copies of their source, are only warned about;
+// enforce is false for them and the policy is applied when they're
+// published.
+func (s *JobService) checkDuplicates(job *domain.Job, others []jobSignature, enforce bool) (jobSignature, error) {
+	signed := signJob(job)
+
+	var matches []uuid.UUID
+	for _, other := range others {
+		if other.job.ID == job.ID {
+			continue
+		}
+		if textsim.SignatureSimilarity(signed.sig, other.sig) >= s.policy.DuplicateThreshold {
+			matches = append(matches, other.job.ID)
+		}
+	}
+	if len(matches) == 0 {
+		return signed, nil
+	}
+
+	if enforce && s.policy.DuplicatePolicy == DuplicatePolicyReject {
+		return signed, &DuplicateJobError{MatchIDs: matches}
+	}
+	job.PossibleDuplicates = matches
+	return signed, nil
+}
+
+// checkPublishDuplicates applies the "reject" duplicate policy to a job that
+// is about to leave draft, or rejected, for the moderation queue or the
+// board. job holds the content being published.
+func (s *JobService) checkPublishDuplicates(ctx context.Context, job *domain.Job, from, to string) error {
+	if s.policy.DuplicatePolicy != DuplicatePolicyReject ||
+		from != domain.JobStatusDraft && from != domain.JobStatusRejected ||
+		to != domain.JobStatusActive && to != domain.JobStatusPendingReview {
+		return nil
+	}
+
+	others, err := s.companySignatures(ctx, job.CompanyID)
+	if err != nil {
+		return err
+	}
+	_, err = s.checkDuplicates(job, others, true)
+	return err
+}
+
+// companySignatures loads and signs the company's live postings.
+func (s *JobService) companySignatures(ctx context.Context, companyID uuid.UUID) ([]jobSignature, error) {
+	if s.policy.DuplicatePolicy == DuplicatePolicyOff || s.policy.DuplicatePolicy == "" {
+		return nil, nil
+	}
+
+	jobs, err := s.jobRepo.ListDuplicateCandidates(ctx, &companyID)
+	if err != nil {
+		return nil, err
+	}
+	signatures := make([]jobSignature, len(jobs))
+	for i := range jobs {
+		signatures[i] = signJob(&jobs[i])
+	}
+	return signatures, nil
+}
+
+// DuplicateReport groups near-duplicate postings across the whole board.
+// Candidate pairs are found by bucketing MinHash bands and then confirmed
+// against the threshold; clusters are returned largest first.
+func (s *JobService) DuplicateReport(ctx context.Context, threshold float64) ([]domain.DuplicateCluster, error) {
+	if threshold <= 0 {
+		threshold = s.policy.DuplicateThreshold
+	}
+
+	jobs, err := s.jobRepo.ListDuplicateCandidates(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]jobSignature, len(jobs))
+	buckets := make(map[uint64][]int)
+	for i := range jobs {
+		signatures[i] = signJob(&jobs[i])
+		for _, key := range textsim.BandKeys(signatures[i].sig, minHashBands) {
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	parent := make([]int, len(jobs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	best := make(map[int]float64)
+	checked := make(map[[2]int]bool)
+	for _, members := range buckets {
+		for a := 0; a < len(members); a++ {
+			for b := a + 1; b < len(members); b++ {
+				pair := [2]int{members[a], members[b]}
+				if checked[pair] {
+					continue
+				}
+				checked[pair] = true
+
+				sim := textsim.SignatureSimilarity(signatures[pair[0]].sig, signatures[pair[1]].sig)
+				if sim < threshold {
+					continue
+				}
+				ra, rb := find(pair[0]), find(pair[1])
+				if ra != rb {
+					parent[rb] = ra
+					if best[rb] > best[ra] {
+						best[ra] = best[rb]
+					}
+				}
+				if sim > best[ra] {
+					best[ra] = sim
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]domain.Job)
+	for i := range jobs {
+		root := find(i)
+		groups[root] = append(groups[root], jobs[i])
+	}
+
+	clusters := []domain.DuplicateCluster{}
+	for root, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		clusters = append(clusters, domain.DuplicateCluster{Similarity: best[root], Jobs: members})
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i].Jobs) != len(clusters[j].Jobs) {
+			return len(clusters[i].Jobs) > len(clusters[j].Jobs)
+		}
+		return clusters[i].Similarity > clusters[j].Similarity
+	})
+
+	return clusters, nil
+}
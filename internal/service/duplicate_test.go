@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+)
+
+// duplicateJobRepository serves a fixed set of postings to the duplicate
+// checks; every other method is left unimplemented.
+type duplicateJobRepository struct {
+	repository.JobRepository
+	jobs []domain.Job
+}
+
+func (r *duplicateJobRepository) ListDuplicateCandidates(ctx context.Context, companyID *uuid.UUID) ([]domain.Job, error) {
+	return r.jobs, nil
+}
+
+func posting(title, description string) domain.Job {
+	return domain.Job{ID: uuid.New(), Title: title, Description: description, Location: "Berlin"}
+}
+
+func TestDuplicateReport(t *testing.T) {
+	const (
+		backend = "We are looking for a backend engineer to design and run payment services in Go " +
+			"with Postgres and Kafka. You will own features end to end, review code and mentor " +
+			"two junior engineers on a small product team."
+		designer = "Join our design team to shape the mobile banking app. You will run user research, " +
+			"build prototypes in Figma and work closely with product managers and engineers " +
+			"on every release."
+		nurse = "The night ward needs a registered nurse for twelve hour shifts caring for post " +
+			"surgery patients, giving medication and keeping records for the morning handover."
+		barista = "Busy coffee shop hiring a part time barista for weekend mornings, pulling espresso, " +
+			"steaming milk and keeping the counter clean while chatting with regulars."
+	)
+
+	backendA := posting("Backend Engineer", backend)
+	backendB := posting("Backend Engineer", backend)
+	backendC := posting("Backend Engineer!", backend+" Apply today.")
+	designerA := posting("Product Designer", designer)
+	designerB := posting("product designer", designer)
+	nurseA := posting("Night Nurse", nurse)
+	baristaA := posting("Barista", barista)
+
+	tests := []struct {
+		name string
+		jobs []domain.Job
+		want [][]uuid.UUID
+	}{
+		{"no jobs", nil, nil},
+		{"all distinct", []domain.Job{backendA, designerA, nurseA, baristaA}, nil},
+		{
+			"one pair",
+			[]domain.Job{nurseA, designerA, baristaA, designerB},
+			[][]uuid.UUID{{designerA.ID, designerB.ID}},
+		},
+		{
+			"largest cluster first",
+			[]domain.Job{designerA, backendA, nurseA, designerB, backendC, baristaA, backendB},
+			[][]uuid.UUID{
+				{backendA.ID, backendB.ID, backendC.ID},
+				{designerA.ID, designerB.ID},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &JobService{
+				jobRepo: &duplicateJobRepository{jobs: tt.jobs},
+				policy:  JobPolicy{DuplicateThreshold: 0.8},
+			}
+			clusters, err := s.DuplicateReport(context.Background(), 0)
+			if err != nil {
+				t.Fatalf("DuplicateReport: %v", err)
+			}
+			if len(clusters) != len(tt.want) {
+				t.Fatalf("got %d clusters, want %d", len(clusters), len(tt.want))
+			}
+			for i, cluster := range clusters {
+				got := make([]uuid.UUID, len(cluster.Jobs))
+				for j, job := range cluster.Jobs {
+					got[j] = job.ID
+				}
+				if !sameIDs(got, tt.want[i]) {
+					t.Errorf("cluster %d = %v, want %v", i, got, tt.want[i])
+				}
+				if cluster.Similarity < 0.8 || cluster.Similarity > 1 {
+					t.Errorf("cluster %d similarity = %v, want within [0.8, 1]", i, cluster.Similarity)
+				}
+			}
+		})
+	}
+}
+
+func TestDuplicateReportThreshold(t *testing.T) {
+	text := "Remote support agent answering customer emails and chats about billing, " +
+		"refunds and account access for a growing software company."
+	a := posting("Support Agent", text)
+	b := posting("Support Agent", text+" Weekend shifts are paid extra and training is provided.")
+
+	s := &JobService{
+		jobRepo: &duplicateJobRepository{jobs: []domain.Job{a, b}},
+		policy:  JobPolicy{DuplicateThreshold: 0.99},
+	}
+
+	strict, err := s.DuplicateReport(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("DuplicateReport: %v", err)
+	}
+	if len(strict) != 0 {
+		t.Errorf("policy threshold 0.99: got %d clusters, want none", len(strict))
+	}
+
+	loose, err := s.DuplicateReport(context.Background(), 0.3)
+	if err != nil {
+		t.Fatalf("DuplicateReport: %v", err)
+	}
+	if len(loose) != 1 || len(loose[0].Jobs) != 2 {
+		t.Errorf("threshold 0.3: got %v, want one cluster of both jobs", loose)
+	}
+}
+
+func sameIDs(a, b []uuid.UUID) bool {
+	less := func(x, y uuid.UUID) int { return slices.Compare(x[:], y[:]) }
+	a, b = slices.SortedFunc(slices.Values(a), less), slices.SortedFunc(slices.Values(b), less)
+	return slices.Equal(a, b)
+}
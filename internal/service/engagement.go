@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/engagement"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+	"github.com/zahidhasann88/job-board-api/pkg/anonymize"
+)
+
+// maxReportDays bounds the date range of funnel and dashboard reports.
+const maxReportDays = 366
+
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+type EngagementService struct {
+	engagementRepo repository.EngagementRepository
+	recorder       *engagement.Recorder
+	trackingSecret string
+}
+
+func NewEngagementService(engagementRepo repository.EngagementRepository, recorder *engagement.Recorder, trackingSecret string) *EngagementService {
+	return &EngagementService{
+		engagementRepo: engagementRepo,
+		recorder:       recorder,
+		trackingSecret: trackingSecret,
+	}
+}
+
+// Record queues an event for each of the jobs. Visitors are identified the
+// same way as in apply click tracking and only their hash is kept.
+func (s *EngagementService) Record(event, visitor string, jobIDs ...uuid.UUID) {
+	hash := anonymize.Hash(s.trackingSecret, visitor)
+	day := dayOf(time.Now())
+	for _, id := range jobIDs {
+		s.recorder.Record(domain.EngagementEvent{
+			JobID:       id,
+			Event:       event,
+			VisitorHash: hash,
+			Day:         day,
+		})
+	}
+}
+
+// JobFunnel reports a job's daily engagement between from and to, both
+// inclusive, with the conversion between each step over the whole range.
+func (s *EngagementService) JobFunnel(ctx context.Context, jobID uuid.UUID, from, to time.Time) (*domain.JobFunnel, error) {
+	from, to = dayOf(from), dayOf(to)
+	if to.Before(from) || to.Sub(from) >= maxReportDays*24*time.Hour {
+		return nil, ErrInvalidDateRange
+	}
+
+	counts, err := s.engagementRepo.DailyCounts(ctx, jobID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	funnel := &domain.JobFunnel{
+		JobID: jobID,
+		From:  from.Format(time.DateOnly),
+		To:    to.Format(time.DateOnly),
+	}
+	index := make(map[string]int)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		date := d.Format(time.DateOnly)
+		index[date] = len(funnel.Days)
+		funnel.Days = append(funnel.Days, domain.FunnelDay{Date: date})
+	}
+
+	for _, c := range counts {
+		i, ok := index[dayOf(c.Day).Format(time.DateOnly)]
+		if !ok {
+			continue
+		}
+		day := &funnel.Days[i]
+		switch c.Event {
+		case domain.EngagementImpression:
+			day.Impressions += c.Visitors
+			funnel.Totals.Impressions += c.Visitors
+		case domain.EngagementView:
+			day.Views += c.Visitors
+			funnel.Totals.Views += c.Visitors
+		case domain.EngagementApplyStart:
+			day.ApplyStarts += c.Visitors
+			funnel.Totals.ApplyStarts += c.Visitors
+		case domain.EngagementApplyComplete:
+			day.ApplyCompletes += c.Visitors
+			funnel.Totals.ApplyCompletes += c.Visitors
+		}
+	}
+
+	t := funnel.Totals
+	funnel.Conversion = domain.FunnelRates{
+		ViewRate:       ratio(t.Views, t.Impressions),
+		ApplyStartRate: ratio(t.ApplyStarts, t.Views),
+		CompletionRate: ratio(t.ApplyCompletes, t.ApplyStarts),
+		Overall:        ratio(t.ApplyCompletes, t.Impressions),
+	}
+	return funnel, nil
+}
+
+// dayOf truncates t to its UTC calendar day.
+func dayOf(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func ratio(n, of int) float64 {
+	if of == 0 {
+		return 0
+	}
+	return float64(n) / float64(of)
+}
@@ -2,35 +2,286 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/matching"
 	"github.com/zahidhasann88/job-board-api/internal/repository"
+	"github.com/zahidhasann88/job-board-api/internal/screening"
+	"github.com/zahidhasann88/job-board-api/internal/similarity"
+	"github.com/zahidhasann88/job-board-api/internal/skills"
 )
 
+// Moderation modes for newly posted jobs
+const (
+	ModerationOff        = "off"
+	ModerationUnverified = "unverified"
+	ModerationAll        = "all"
+)
+
+var (
+	ErrJobNotFound             = errors.New("job not found")
+	ErrInvalidJobStatus        = errors.New("invalid job status")
+	ErrInvalidStatusTransition = errors.New("invalid job status transition")
+	ErrRejectionReasonRequired = errors.New("a reason is required when rejecting a job")
+	ErrJobBlocked              = errors.New("job posting was blocked by fraud screening")
+	ErrVersionConflict         = repository.ErrVersionConflict
+)
+
+// jobStatusTransitions lists the statuses a recruiter may move a job to from
+// its current status. Leaving pending_review for active or rejected is
+// reserved for moderators and goes through ReviewJob.
+var jobStatusTransitions = map[string][]string{
+	domain.JobStatusDraft:         {domain.JobStatusActive, domain.JobStatusPendingReview, domain.JobStatusClosed},
+	domain.JobStatusPendingReview: {domain.JobStatusDraft, domain.JobStatusClosed},
+	domain.JobStatusActive:        {domain.JobStatusInactive, domain.JobStatusClosed, domain.JobStatusDraft},
+	domain.JobStatusInactive:      {domain.JobStatusActive, domain.JobStatusClosed},
+	domain.JobStatusClosed:        {domain.JobStatusActive, domain.JobStatusDraft},
+	domain.JobStatusRejected:      {domain.JobStatusDraft, domain.JobStatusPendingReview},
+}
+
+// JobPolicy holds the configurable rules applied to new and changed postings.
+type JobPolicy struct {
+	ModerationMode     string
+	NewCompanyDays     int
+	DuplicatePolicy    string
+	DuplicateThreshold float64
+	ExpiryReminderDays int
+	RenewalDays        int
+	RetentionDays      int
+
+	// Key for hashing visitors who click through to external jobs
+	TrackingSecret string
+
+	// How much each factor counts when matching candidates to jobs
+	MatchWeights matching.Weights
+}
+
 type JobService struct {
-	jobRepo repository.JobRepository
+	jobRepo             repository.JobRepository
+	revisionRepo        repository.JobRevisionRepository
+	clickRepo           repository.ApplyClickRepository
+	userRepo            repository.UserRepository
+	notificationService *NotificationService
+	screener            *screening.Pipeline
+	taxonomy            *skills.Taxonomy
+	matcher             *matching.Engine
+	similar             *similarity.Index
+	policy              JobPolicy
 }
 
-func NewJobService(jobRepo repository.JobRepository) *JobService {
-	return &JobService{jobRepo: jobRepo}
+func NewJobService(
+	jobRepo repository.JobRepository,
+	revisionRepo repository.JobRevisionRepository,
+	clickRepo repository.ApplyClickRepository,
+	userRepo repository.UserRepository,
+	notificationService *NotificationService,
+	screener *screening.Pipeline,
+	taxonomy *skills.Taxonomy,
+	similar *similarity.Index,
+	policy JobPolicy,
+) *JobService {
+	return &JobService{
+		jobRepo:             jobRepo,
+		revisionRepo:        revisionRepo,
+		clickRepo:           clickRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		screener:            screener,
+		taxonomy:            taxonomy,
+		matcher:             matching.NewEngine(policy.MatchWeights),
+		similar:             similar,
+		policy:              policy,
+	}
 }
 
 func (s *JobService) CreateJob(ctx context.Context, job *domain.Job) error {
+	return s.createJob(ctx, job, false)
+}
+
+// CreateDraftJob creates a job that stays a draft until the recruiter
+// publishes it, such as a clone or a job created from a template.
+func (s *JobService) CreateDraftJob(ctx context.Context, job *domain.Job) error {
+	return s.createJob(ctx, job, true)
+}
+
+func (s *JobService) createJob(ctx context.Context, job *domain.Job, draft bool) error {
 	job.ID = uuid.New()
-	job.Status = "active"
+
+	if err := validateSchedule(job); err != nil {
+		return err
+	}
+	if err := normalizeApply(job); err != nil {
+		return err
+	}
+	job.Skills = s.taxonomy.Normalize(job.Skills)
+	if job.Openings < 1 {
+		job.Openings = 1
+	}
+
+	draft = draft || scheduledForLater(job, time.Now())
+	others, err := s.companySignatures(ctx, job.CompanyID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.checkDuplicates(job, others, !draft); err != nil {
+		return err
+	}
+
+	result, err := s.screen(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	status, err := s.publishStatus(ctx, job.CompanyID)
+	if err != nil {
+		return err
+	}
+	if draft {
+		status = domain.JobStatusDraft
+	}
+	if result != nil && result.Decision == screening.DecisionReview {
+		status = domain.JobStatusPendingReview
+	}
+	job.Status = status
+
 	return s.jobRepo.Create(ctx, job)
 }
 
-func (s *JobService) UpdateJob(ctx context.Context, job *domain.Job) error {
-	return s.jobRepo.Update(ctx, job)
+func (s *JobService) UpdateJob(ctx context.Context, job *domain.Job, actorID uuid.UUID) error {
+	return s.updateJob(ctx, job, &actorID, domain.RevisionUpdated)
+}
+
+func (s *JobService) updateJob(ctx context.Context, job *domain.Job, actorID *uuid.UUID, changeType string) error {
+	existing, err := s.jobRepo.GetByID(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrJobNotFound
+	}
+
+	// A zero version means the caller didn't ask for a concurrency check
+	if job.Version == 0 {
+		job.Version = existing.Version
+	} else if job.Version != existing.Version {
+		return ErrVersionConflict
+	}
+
+	job.CompanyID = existing.CompanyID
+	job.PublishedAt = existing.PublishedAt
+	job.ReviewedAt = existing.ReviewedAt
+	if job.Openings < 1 {
+		job.Openings = existing.Openings
+	}
+
+	if err := validateSchedule(job); err != nil {
+		return err
+	}
+	if job.ApplyMethod == "" && job.ApplyURL == nil {
+		job.ApplyMethod, job.ApplyURL = existing.ApplyMethod, existing.ApplyURL
+	}
+	if err := normalizeApply(job); err != nil {
+		return err
+	}
+	job.Skills = s.taxonomy.Normalize(job.Skills)
+
+	result, err := s.screen(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	// Suspicious edits lose the job's approval, so it can't go live again
+	// without a new review
+	if result != nil && result.Decision == screening.DecisionReview {
+		job.ReviewedAt = nil
+	}
+
+	if job.Status == "" {
+		job.Status = existing.Status
+	}
+	if job.Status != existing.Status {
+		// The change is judged on the screening of the edited content
+		existing.RiskScore, existing.ReviewedAt = job.RiskScore, job.ReviewedAt
+		status, err := s.resolveStatusChange(ctx, existing, job.Status)
+		if err != nil {
+			return err
+		}
+		if err := s.checkPublishDuplicates(ctx, job, existing.Status, status); err != nil {
+			return err
+		}
+		job.Status = status
+	}
+
+	// Suspicious edits to a live posting take it back to the moderation queue
+	if result != nil && result.Decision == screening.DecisionReview && job.Status == domain.JobStatusActive {
+		job.Status = domain.JobStatusPendingReview
+	}
+
+	return s.jobRepo.Update(ctx, job, changeType, actorID)
+}
+
+// screen runs fraud screening over a posting and records the score on the
+// job. Postings scoring above the block threshold return ErrJobBlocked.
+func (s *JobService) screen(ctx context.Context, job *domain.Job) (*screening.Result, error) {
+	if s.screener == nil {
+		return nil, nil
+	}
+
+	in := &screening.Input{
+		JobID:       job.ID,
+		Title:       job.Title,
+		Description: job.Description,
+		Location:    job.Location,
+		SalaryRange: job.SalaryRange,
+		Skills:      job.Skills,
+		PosterID:    job.CompanyID,
+	}
+	poster, err := s.userRepo.GetByID(ctx, job.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if poster != nil {
+		in.PosterCreatedAt = poster.CreatedAt
+		in.PosterVerified = poster.Verified
+	}
+
+	result, err := s.screener.Score(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	job.RiskScore = &result.Score
+	job.RiskReasons = result.Reasons
+
+	if result.Decision == screening.DecisionBlock {
+		return result, ErrJobBlocked
+	}
+	return result, nil
 }
 
 func (s *JobService) GetJob(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
 	return s.jobRepo.GetByID(ctx, id)
 }
 
+// GetVisibleJob returns a job if the viewer may see it, or nil. Active and
+// closed jobs are public; other statuses only show to the company that
+// posted the job and to admins. viewerID is nil for anonymous viewers.
+func (s *JobService) GetVisibleJob(ctx context.Context, id uuid.UUID, viewerID *uuid.UUID, viewerRole domain.UserRole) (*domain.Job, error) {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil || job == nil {
+		return nil, err
+	}
+	if job.Status == domain.JobStatusActive || job.Status == domain.JobStatusClosed ||
+		viewerRole == domain.RoleAdmin || viewerID != nil && *viewerID == job.CompanyID {
+		return job, nil
+	}
+	return nil, nil
+}
+
 func (s *JobService) ListJobs(ctx context.Context, filter domain.JobFilter) ([]domain.Job, int, error) {
 	if filter.Page < 1 {
 		filter.Page = 1
@@ -38,20 +289,191 @@ func (s *JobService) ListJobs(ctx context.Context, filter domain.JobFilter) ([]d
 	if filter.PageSize < 1 {
 		filter.PageSize = 10
 	}
+	filter.Skills = s.taxonomy.Expand(filter.Skills)
 	return s.jobRepo.List(ctx, filter)
 }
 
-func (s *JobService) ChangeJobStatus(ctx context.Context, id uuid.UUID, status string) error {
-	// Add validation for allowed status values
-	allowedStatuses := []string{"active", "inactive", "closed", "draft"}
-	if !contains(allowedStatuses, status) {
-		return fmt.Errorf("invalid job status: %s", status)
+// ChangeJobStatus applies a status change requested by actorID, or by the
+// system when actorID is nil.
+func (s *JobService) ChangeJobStatus(ctx context.Context, id uuid.UUID, status string, actorID *uuid.UUID) error {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return ErrJobNotFound
 	}
-	return s.jobRepo.ChangeJobStatus(ctx, id, status)
+
+	to, err := s.resolveStatusChange(ctx, job, status)
+	if err != nil {
+		return err
+	}
+	if err := s.checkPublishDuplicates(ctx, job, job.Status, to); err != nil {
+		return err
+	}
+	return s.setStatus(ctx, id, to, actorID)
+}
+
+// setStatus writes a status change that has already been validated; the
+// repository records it in the job's revision history.
+func (s *JobService) setStatus(ctx context.Context, id uuid.UUID, status string, actorID *uuid.UUID) error {
+	return s.jobRepo.ChangeJobStatus(ctx, id, status, actorID)
+}
+
+// resolveStatusChange validates a recruiter-initiated status change and
+// returns the status the job should actually end up in. Activating a job
+// that isn't approved, because it was never reviewed, its last review was a
+// rejection or a suspicious edit was made since, is routed to the moderation
+// queue when the company requires review or screening flagged the job.
+func (s *JobService) resolveStatusChange(ctx context.Context, job *domain.Job, status string) (string, error) {
+	if _, ok := jobStatusTransitions[status]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidJobStatus, status)
+	}
+	if status == job.Status {
+		return status, nil
+	}
+	if !contains(jobStatusTransitions[job.Status], status) {
+		return "", fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, job.Status, status)
+	}
+
+	if status == domain.JobStatusActive && job.ReviewedAt == nil {
+		if s.flagged(job) {
+			return domain.JobStatusPendingReview, nil
+		}
+		return s.publishStatus(ctx, job.CompanyID)
+	}
+	return status, nil
+}
+
+// flagged reports whether the job's last screening asked for a review.
+func (s *JobService) flagged(job *domain.Job) bool {
+	return s.screener != nil && job.RiskScore != nil && s.screener.NeedsReview(*job.RiskScore)
+}
+
+// publishStatus returns the status a job should get when its company asks to
+// publish it: active, or pending_review if the moderation mode requires it.
+func (s *JobService) publishStatus(ctx context.Context, companyID uuid.UUID) (string, error) {
+	required, err := s.requiresModeration(ctx, companyID)
+	if err != nil {
+		return "", err
+	}
+	if required {
+		return domain.JobStatusPendingReview, nil
+	}
+	return domain.JobStatusActive, nil
 }
 
-func (s *JobService) CompleteDeleteJob(ctx context.Context, id uuid.UUID) error {
-	return s.jobRepo.Delete(ctx, id)
+func (s *JobService) requiresModeration(ctx context.Context, companyID uuid.UUID) (bool, error) {
+	switch s.policy.ModerationMode {
+	case ModerationAll:
+		return true, nil
+	case ModerationUnverified:
+		company, err := s.userRepo.GetByID(ctx, companyID)
+		if err != nil {
+			return false, err
+		}
+		if company == nil || !company.Verified {
+			return true, nil
+		}
+		newCompanyAge := time.Duration(s.policy.NewCompanyDays) * 24 * time.Hour
+		return time.Since(company.CreatedAt) < newCompanyAge, nil
+	default:
+		return false, nil
+	}
+}
+
+// ListModerationQueue returns jobs awaiting review, oldest first, along with
+// their fraud screening results.
+func (s *JobService) ListModerationQueue(ctx context.Context, page, pageSize int) ([]domain.ModerationQueueItem, int, error) {
+	status := domain.JobStatusPendingReview
+	jobs, total, err := s.ListJobs(ctx, domain.JobFilter{
+		Status:      &status,
+		OldestFirst: true,
+		Page:        page,
+		PageSize:    pageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]domain.ModerationQueueItem, len(jobs))
+	for i, job := range jobs {
+		items[i] = domain.ModerationQueueItem{
+			Job:         job,
+			RiskScore:   job.RiskScore,
+			RiskReasons: job.RiskReasons,
+		}
+	}
+	return items, total, nil
+}
+
+// ReviewJob approves or rejects a job in the moderation queue and notifies
+// the recruiter who posted it. Jobs rejected as fraud are compared against by
+// fraud screening; fraud is ignored for approvals.
+func (s *JobService) ReviewJob(ctx context.Context, id, reviewerID uuid.UUID, approve bool, reason string, fraud bool) (*domain.Job, error) {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrJobNotFound
+	}
+	if job.Status != domain.JobStatusPendingReview {
+		return nil, fmt.Errorf("%w: job is %s, not %s", ErrInvalidStatusTransition, job.Status, domain.JobStatusPendingReview)
+	}
+
+	reason = strings.TrimSpace(reason)
+	status := domain.JobStatusActive
+	if scheduledForLater(job, time.Now()) {
+		// Approved ahead of its publish time; the scheduler publishes it
+		status = domain.JobStatusDraft
+	}
+	if !approve {
+		if reason == "" {
+			return nil, ErrRejectionReasonRequired
+		}
+		status = domain.JobStatusRejected
+	}
+
+	var note *string
+	if reason != "" {
+		note = &reason
+	}
+	if err := s.jobRepo.ReviewJob(ctx, id, status, reviewerID, note, fraud && !approve); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job.Status = status
+	job.ModerationNote = note
+	job.ReviewedBy = &reviewerID
+	job.ReviewedAt = nil
+	if approve {
+		job.ReviewedAt = &now
+	}
+
+	if approve && status == domain.JobStatusDraft {
+		err = s.notificationService.Notify(ctx, job.CompanyID, domain.NotificationJobApproved,
+			"Job approved",
+			fmt.Sprintf("Your job \"%s\" has been approved and will be published on %s.",
+				job.Title, job.PublishAt.Format("2006-01-02 15:04 MST")),
+			&job.ID)
+	} else if approve {
+		err = s.notificationService.Notify(ctx, job.CompanyID, domain.NotificationJobApproved,
+			"Job approved",
+			fmt.Sprintf("Your job \"%s\" has been approved and is now live.", job.Title),
+			&job.ID)
+	} else {
+		err = s.notificationService.Notify(ctx, job.CompanyID, domain.NotificationJobRejected,
+			"Job rejected",
+			fmt.Sprintf("Your job \"%s\" was rejected by a moderator: %s", job.Title, reason),
+			&job.ID)
+	}
+	if err != nil {
+		return job, fmt.Errorf("job reviewed but notification failed: %w", err)
+	}
+
+	return job, nil
 }
 
 func contains(slice []string, str string) bool {
@@ -67,34 +489,92 @@ func (s *JobService) GetJobAnalytics(ctx context.Context, companyID uuid.UUID) (
 	return s.jobRepo.GetJobAnalytics(ctx, companyID)
 }
 
-func (s *JobService) BulkCreateJobs(ctx context.Context, requests []domain.CreateJobRequest, companyID uuid.UUID) ([]domain.Job, error) {
-	jobs := make([]domain.Job, len(requests))
-	for i, req := range requests {
-		jobs[i] = domain.Job{
-			ID:              uuid.New(),
-			Title:           req.Title,
-			Description:     req.Description,
-			CompanyID:       companyID,
-			Location:        req.Location,
-			SalaryRange:     req.SalaryRange,
-			JobType:         req.JobType,
-			ExperienceLevel: req.ExperienceLevel,
-			Skills:          req.Skills,
-			Status:          "active",
-		}
+func jobFromRequest(companyID uuid.UUID, status string, req domain.CreateJobRequest) domain.Job {
+	return domain.Job{
+		ID:                  uuid.New(),
+		Title:               req.Title,
+		Description:         req.Description,
+		CompanyID:           companyID,
+		Location:            req.Location,
+		SalaryRange:         req.SalaryRange,
+		JobType:             req.JobType,
+		ExperienceLevel:     req.ExperienceLevel,
+		Skills:              req.Skills,
+		Status:              status,
+		Openings:            req.Openings,
+		AutoRejectRemaining: req.AutoRejectRemaining,
+		ApplyMethod:         req.ApplyMethod,
+		ApplyURL:            req.ApplyURL,
+		PublishAt:           req.PublishAt,
+		ExpiresAt:           req.ExpiresAt,
+		ApplicationDeadline: req.ApplicationDeadline,
 	}
+}
 
-	if err := s.jobRepo.BulkCreate(ctx, jobs); err != nil {
-		return nil, err
+// prepareBatchJob runs the checks CreateJob applies on one posting of a
+// batch. Each posting is checked for duplicates against others, which holds
+// the company's live jobs and the postings earlier in the batch.
+func (s *JobService) prepareBatchJob(ctx context.Context, job *domain.Job, now time.Time, others *[]jobSignature) error {
+	if err := validateSchedule(job); err != nil {
+		return err
+	}
+	if err := normalizeApply(job); err != nil {
+		return err
+	}
+	job.Skills = s.taxonomy.Normalize(job.Skills)
+	if job.Openings < 1 {
+		job.Openings = 1
+	}
+	if scheduledForLater(job, now) {
+		job.Status = domain.JobStatusDraft
 	}
 
-	return jobs, nil
+	if s.policy.DuplicatePolicy != DuplicatePolicyOff && s.policy.DuplicatePolicy != "" {
+		signed, err := s.checkDuplicates(job, *others, job.Status != domain.JobStatusDraft)
+		if err != nil {
+			return err
+		}
+		*others = append(*others, signed)
+	}
+
+	result, err := s.screen(ctx, job)
+	if err != nil {
+		return err
+	}
+	if result != nil && result.Decision == screening.DecisionReview {
+		job.Status = domain.JobStatusPendingReview
+	}
+	return nil
 }
 
 func (s *JobService) GetJobApplicationInsights(ctx context.Context, jobID uuid.UUID) (*domain.JobApplicationInsights, error) {
-	return s.jobRepo.GetApplicationInsights(ctx, jobID)
-}
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrJobNotFound
+	}
+
+	insights, err := s.jobRepo.GetApplicationInsights(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	backgrounds, err := s.jobRepo.ListApplicantBackgrounds(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	insights.Experience = experienceStats(backgrounds, time.Now())
+	insights.SkillCoverage = skillCoverage(job.Skills, backgrounds)
+	insights.EducationLevels = educationLevels(backgrounds)
+
+	insights.Openings = job.Openings
+	insights.FilledPositions = insights.ApplicationsByStatus[domain.ApplicationStatusAccepted]
+	if insights.FilledPositions > job.Openings {
+		insights.FilledPositions = job.Openings
+	}
+	insights.OpenPositions = job.Openings - insights.FilledPositions
 
-func (s *JobService) GetRecommendedCandidates(ctx context.Context, jobID uuid.UUID) ([]domain.RecommendedCandidate, error) {
-	return s.jobRepo.GetRecommendedCandidates(ctx, jobID)
+	return insights, nil
 }
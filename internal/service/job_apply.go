@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/pkg/anonymize"
+)
+
+var (
+	ErrInvalidApplyMethod = errors.New("invalid apply method")
+	ErrNotExternalJob     = errors.New("job takes applications on the board")
+)
+
+// normalizeApply checks a job's apply method and URL. A job with an apply
+// URL and no method is external; internal jobs don't keep a URL.
+func normalizeApply(job *domain.Job) error {
+	if job.ApplyMethod == "" {
+		job.ApplyMethod = domain.ApplyMethodInternal
+		if job.ApplyURL != nil && *job.ApplyURL != "" {
+			job.ApplyMethod = domain.ApplyMethodExternal
+		}
+	}
+
+	switch job.ApplyMethod {
+	case domain.ApplyMethodInternal:
+		job.ApplyURL = nil
+		return nil
+	case domain.ApplyMethodExternal:
+		if job.ApplyURL == nil || *job.ApplyURL == "" {
+			return fmt.Errorf("%w: external jobs need an apply_url", ErrInvalidApplyMethod)
+		}
+		u, err := url.Parse(*job.ApplyURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: apply_url must be an http or https URL", ErrInvalidApplyMethod)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidApplyMethod, job.ApplyMethod)
+	}
+}
+
+// TrackApplyClick records a click through to an external job's apply URL and
+// returns the URL. Visitor identifies the person clicking, such as a user ID
+// or an IP address and user agent; only a keyed hash of it is stored.
+func (s *JobService) TrackApplyClick(ctx context.Context, jobID uuid.UUID, referrer, visitor string) (string, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return "", err
+	}
+	if job == nil || job.Status != domain.JobStatusActive {
+		return "", ErrJobNotFound
+	}
+	if job.ApplyMethod != domain.ApplyMethodExternal || job.ApplyURL == nil {
+		return "", ErrNotExternalJob
+	}
+
+	click := &domain.ApplyClick{
+		ID:          uuid.New(),
+		JobID:       jobID,
+		VisitorHash: anonymize.Hash(s.policy.TrackingSecret, visitor),
+	}
+	if referrer != "" {
+		click.Referrer = &referrer
+	}
+	if err := s.clickRepo.Create(ctx, click); err != nil {
+		return "", err
+	}
+	return *job.ApplyURL, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// ImportJobs creates jobs from parsed spreadsheet rows and reports the
+// outcome of every row. Rows are checked like the postings of a bulk
+// create. In all-or-nothing mode nothing is created unless every row
+// passes; in best-effort mode the passing rows are created. A dry run checks
+// the rows without creating anything.
+func (s *JobService) ImportJobs(ctx context.Context, companyID uuid.UUID, rows []domain.JobImportRow, opts domain.JobImportOptions) (*domain.JobImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = domain.ImportModeAllOrNothing
+	}
+
+	status, err := s.publishStatus(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	others, err := s.companySignatures(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.JobImportReport{
+		Mode:   opts.Mode,
+		DryRun: opts.DryRun,
+		Total:  len(rows),
+		Rows:   make([]domain.JobImportRowResult, len(rows)),
+	}
+
+	var jobs []domain.Job
+	var jobRows []int
+	for i, row := range rows {
+		result := &report.Rows[i]
+		result.Line = row.Line
+
+		switch {
+		case row.Blank:
+			result.Status = domain.ImportRowSkipped
+			result.Message = "blank row"
+		case len(row.Errors) > 0:
+			result.Status = domain.ImportRowError
+			result.Errors = row.Errors
+			report.Failed++
+		default:
+			job := jobFromRequest(companyID, status, row.Request)
+			if err := s.prepareBatchJob(ctx, &job, now, &others); err != nil {
+				result.Status = domain.ImportRowError
+				result.Message = err.Error()
+				report.Failed++
+				continue
+			}
+			jobs = append(jobs, job)
+			jobRows = append(jobRows, i)
+		}
+	}
+
+	create := !opts.DryRun && len(jobs) > 0 &&
+		(report.Failed == 0 || opts.Mode == domain.ImportModeBestEffort)
+	if create {
+		if err := s.jobRepo.BulkCreate(ctx, jobs); err != nil {
+			return nil, err
+		}
+	}
+
+	for n, i := range jobRows {
+		result := &report.Rows[i]
+		switch {
+		case opts.DryRun:
+			result.Status = domain.ImportRowValid
+		case create:
+			id := jobs[n].ID
+			result.Status = domain.ImportRowCreated
+			result.JobID = &id
+			report.Created++
+		default:
+			result.Status = domain.ImportRowSkipped
+			result.Message = "not imported because other rows failed"
+		}
+	}
+	for _, result := range report.Rows {
+		if result.Status == domain.ImportRowSkipped {
+			report.Skipped++
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// Education levels, from highest to lowest
+const (
+	EducationDoctorate  = "doctorate"
+	EducationMaster     = "master"
+	EducationBachelor   = "bachelor"
+	EducationAssociate  = "associate"
+	EducationHighSchool = "high_school"
+	EducationOther      = "other"
+	EducationUnknown    = "unknown"
+)
+
+// educationKeywords recognizes a level from the words of a degree name, with
+// dots and apostrophes removed so "B.Sc." reads as "bsc".
+var educationKeywords = []struct {
+	level string
+	words []string
+}{
+	{EducationDoctorate, []string{"phd", "doctorate", "doctor", "doctoral", "dphil", "edd"}},
+	{EducationMaster, []string{"master", "masters", "msc", "ma", "ms", "mba", "meng", "mphil", "mtech"}},
+	{EducationBachelor, []string{"bachelor", "bachelors", "bsc", "ba", "bs", "beng", "btech", "bba"}},
+	{EducationAssociate, []string{"associate", "associates"}},
+	{EducationHighSchool, []string{"high", "secondary", "ged"}},
+}
+
+// experienceStats summarizes applicants' years of work from their employment
+// history.
+func experienceStats(backgrounds []domain.ApplicantBackground, now time.Time) domain.ExperienceStats {
+	var years []float64
+	for _, b := range backgrounds {
+		if len(b.Employment) > 0 {
+			years = append(years, domain.YearsOfExperience(b.Employment, now))
+		}
+	}
+
+	stats := durationStats(years)
+	return domain.ExperienceStats{
+		Applicants:   stats.Count,
+		AverageYears: stats.Average,
+		MedianYears:  stats.Median,
+	}
+}
+
+// skillCoverage counts, for each of the job's skills, the applicants listing
+// it on their profile, ignoring case.
+func skillCoverage(skills []string, backgrounds []domain.ApplicantBackground) []domain.SkillCoverage {
+	coverage := make([]domain.SkillCoverage, 0, len(skills))
+	for _, skill := range skills {
+		c := domain.SkillCoverage{Skill: skill}
+		for _, b := range backgrounds {
+			if slices.ContainsFunc(b.Skills, func(s string) bool { return strings.EqualFold(s, skill) }) {
+				c.Applicants++
+			}
+		}
+		c.Share = ratio(c.Applicants, len(backgrounds))
+		coverage = append(coverage, c)
+	}
+	return coverage
+}
+
+// educationLevels counts applicants by the highest level among their degrees.
+func educationLevels(backgrounds []domain.ApplicantBackground) map[string]int {
+	levels := make(map[string]int)
+	for _, b := range backgrounds {
+		if len(b.Degrees) == 0 {
+			levels[EducationUnknown]++
+			continue
+		}
+		best := len(educationKeywords)
+		for _, degree := range b.Degrees {
+			if rank := educationRank(degree); rank < best {
+				best = rank
+			}
+		}
+		if best == len(educationKeywords) {
+			levels[EducationOther]++
+		} else {
+			levels[educationKeywords[best].level]++
+		}
+	}
+	return levels
+}
+
+// educationRank returns the index of the degree's level in educationKeywords,
+// or len(educationKeywords) when it isn't recognized.
+func educationRank(degree string) int {
+	degree = strings.NewReplacer(".", "", "'", "", "’", "").Replace(strings.ToLower(degree))
+	words := strings.FieldsFunc(degree, func(r rune) bool { return !unicode.IsLetter(r) })
+	for rank, level := range educationKeywords {
+		for _, w := range words {
+			if slices.Contains(level.words, w) {
+				return rank
+			}
+		}
+	}
+	return len(educationKeywords)
+}
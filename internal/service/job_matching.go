@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/matching"
+)
+
+// GetRecommendedCandidates ranks job seekers who haven't applied to the job
+// by how well they match it, best first. When the job lists skills, only
+// candidates with at least one of them are recommended.
+func (s *JobService) GetRecommendedCandidates(ctx context.Context, jobID uuid.UUID, page, pageSize int) ([]domain.RecommendedCandidate, int, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if job == nil {
+		return nil, 0, ErrJobNotFound
+	}
+
+	profiles, err := s.userRepo.ListCandidateProfiles(ctx, jobID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	candidates := make([]domain.RecommendedCandidate, 0, len(profiles))
+	for i := range profiles {
+		p := &profiles[i]
+		match := s.matcher.Score(job, p)
+		if len(job.Skills) > 0 && match.Factor(domain.MatchFactorSkills).Score == 0 {
+			continue
+		}
+		candidates = append(candidates, domain.RecommendedCandidate{
+			UserID:            p.UserID,
+			FullName:          p.FullName,
+			Email:             p.Email,
+			Location:          p.Location,
+			Skills:            p.Skills,
+			YearsOfExperience: domain.YearsOfExperience(p.EmploymentHistory, now),
+			Match:             match,
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+
+	start, end := pageBounds(len(candidates), page, pageSize)
+	return candidates[start:end], len(candidates), nil
+}
+
+// RecommendJobs ranks active jobs for a job seeker, best first, leaving out
+// jobs they applied to or marked as not interested.
+func (s *JobService) RecommendJobs(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]domain.RecommendedJob, int, error) {
+	seeker, err := s.userRepo.GetCandidateProfile(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if seeker == nil {
+		return nil, 0, ErrUserNotFound
+	}
+
+	var prefs matching.Preferences
+	if prefs.Applied, err = s.jobRepo.ListAppliedJobs(ctx, userID); err != nil {
+		return nil, 0, err
+	}
+	if prefs.NotInterested, err = s.jobRepo.ListNotInterested(ctx, userID); err != nil {
+		return nil, 0, err
+	}
+	skip := make(map[uuid.UUID]bool)
+	for _, job := range prefs.Applied {
+		skip[job.ID] = true
+	}
+	for _, job := range prefs.NotInterested {
+		skip[job.ID] = true
+	}
+
+	var recommended []domain.RecommendedJob
+	status := domain.JobStatusActive
+	err = s.jobRepo.Stream(ctx, domain.JobFilter{Status: &status}, func(job *domain.Job) error {
+		if !skip[job.ID] {
+			recommended = append(recommended, domain.RecommendedJob{
+				Job:   *job,
+				Match: s.matcher.ScoreJob(job, seeker, prefs),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	sort.SliceStable(recommended, func(i, j int) bool { return recommended[i].Score > recommended[j].Score })
+
+	start, end := pageBounds(len(recommended), page, pageSize)
+	return recommended[start:end], len(recommended), nil
+}
+
+// MarkNotInterested hides the job from the seeker's recommendations and
+// ranks jobs like it lower.
+func (s *JobService) MarkNotInterested(ctx context.Context, userID, jobID uuid.UUID) error {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return ErrJobNotFound
+	}
+	return s.jobRepo.MarkNotInterested(ctx, userID, jobID)
+}
+
+func (s *JobService) ClearNotInterested(ctx context.Context, userID, jobID uuid.UUID) error {
+	return s.jobRepo.ClearNotInterested(ctx, userID, jobID)
+}
+
+// pageBounds returns the slice bounds of a page of n items. Pages past the
+// end are empty, however large page is.
+func pageBounds(n, page, pageSize int) (int, int) {
+	if page-1 > n/pageSize {
+		return n, n
+	}
+	start := min((page-1)*pageSize, n)
+	return start, min(start+pageSize, n)
+}
+
+// SimilarJobs returns up to limit active jobs like the given one, most
+// similar first, from the in-memory similarity index. The given job must be
+// one the viewer may see.
+func (s *JobService) SimilarJobs(ctx context.Context, jobID uuid.UUID, viewerID *uuid.UUID, viewerRole domain.UserRole, limit int) ([]domain.SimilarJob, error) {
+	job, err := s.GetVisibleJob(ctx, jobID, viewerID, viewerRole)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrJobNotFound
+	}
+
+	similar := []domain.SimilarJob{}
+	for _, result := range s.similar.Similar(jobID, limit) {
+		other, err := s.jobRepo.GetByID(ctx, result.JobID)
+		if err != nil {
+			return nil, err
+		}
+		// The index may briefly lag behind changes made elsewhere
+		if other == nil || other.Status != domain.JobStatusActive {
+			continue
+		}
+		similar = append(similar, domain.SimilarJob{Job: *other, Similarity: result.Score})
+	}
+	return similar, nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPageBounds(t *testing.T) {
+	tests := []struct {
+		name               string
+		n, page, pageSize  int
+		wantStart, wantEnd int
+	}{
+		{"first page", 25, 1, 10, 0, 10},
+		{"last partial page", 25, 3, 10, 20, 25},
+		{"exact last page", 20, 2, 10, 10, 20},
+		{"past the end", 25, 4, 10, 25, 25},
+		{"no items", 0, 1, 10, 0, 0},
+		{"huge page", 25, math.MaxInt, 100, 25, 25},
+		{"page that overflows to a valid start", 25, math.MaxInt/10 + 2, 10, 25, 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := pageBounds(tt.n, tt.page, tt.pageSize)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("pageBounds(%d, %d, %d) = %d, %d; want %d, %d",
+					tt.n, tt.page, tt.pageSize, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+// GetCompany returns the recruiter account jobs are posted under, or nil if
+// it doesn't exist.
+func (s *JobService) GetCompany(ctx context.Context, companyID uuid.UUID) (*domain.User, error) {
+	return s.userRepo.GetByID(ctx, companyID)
+}
+
+// ListActiveJobs returns a page of active jobs, oldest first, and the number
+// of active jobs.
+func (s *JobService) ListActiveJobs(ctx context.Context, page, pageSize int) ([]domain.Job, int, error) {
+	status := domain.JobStatusActive
+	return s.ListJobs(ctx, domain.JobFilter{
+		Status:      &status,
+		OldestFirst: true,
+		Page:        page,
+		PageSize:    pageSize,
+	})
+}
+
+// JobFeedState returns the newest update among the jobs matching filter and
+// how many there are, which together identify a version of a feed.
+func (s *JobService) JobFeedState(ctx context.Context, filter domain.JobFilter) (time.Time, int, error) {
+	filter.Skills = s.taxonomy.Expand(filter.Skills)
+	return s.jobRepo.LastUpdated(ctx, filter)
+}
+
+// StreamJobs calls fn for every job matching filter, newest first, together
+// with the company that posted it. Each company is looked up once.
+func (s *JobService) StreamJobs(ctx context.Context, filter domain.JobFilter, fn func(*domain.Job, *domain.User) error) error {
+	filter.Skills = s.taxonomy.Expand(filter.Skills)
+	companies := make(map[uuid.UUID]*domain.User)
+	return s.jobRepo.Stream(ctx, filter, func(job *domain.Job) error {
+		company, ok := companies[job.CompanyID]
+		if !ok {
+			var err error
+			if company, err = s.userRepo.GetByID(ctx, job.CompanyID); err != nil {
+				return err
+			}
+			companies[job.CompanyID] = company
+		}
+		return fn(job, company)
+	})
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+var ErrRevisionNotFound = errors.New("job revision not found")
+
+func (s *JobService) ListRevisions(ctx context.Context, jobID uuid.UUID) ([]domain.JobRevision, error) {
+	return s.revisionRepo.List(ctx, jobID)
+}
+
+func (s *JobService) getRevision(ctx context.Context, jobID uuid.UUID, revision int) (*domain.JobRevision, error) {
+	rev, err := s.revisionRepo.Get(ctx, jobID, revision)
+	if err != nil {
+		return nil, err
+	}
+	if rev == nil {
+		return nil, ErrRevisionNotFound
+	}
+	return rev, nil
+}
+
+// DiffRevisions lists the fields that differ between two revisions of a job.
+// updated_at is left out since it changes with every revision.
+func (s *JobService) DiffRevisions(ctx context.Context, jobID uuid.UUID, from, to int) (*domain.JobRevisionDiff, error) {
+	fromRev, err := s.getRevision(ctx, jobID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRev, err := s.getRevision(ctx, jobID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	fromFields, err := jobFields(&fromRev.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := jobFields(&toRev.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]struct{})
+	for name := range fromFields {
+		names[name] = struct{}{}
+	}
+	for name := range toFields {
+		names[name] = struct{}{}
+	}
+	delete(names, "updated_at")
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	diff := &domain.JobRevisionDiff{JobID: jobID, From: from, To: to, Changes: []domain.FieldChange{}}
+	for _, name := range sorted {
+		if !reflect.DeepEqual(fromFields[name], toFields[name]) {
+			diff.Changes = append(diff.Changes, domain.FieldChange{
+				Field: name,
+				From:  fromFields[name],
+				To:    toFields[name],
+			})
+		}
+	}
+	return diff, nil
+}
+
+// RestoreRevision puts a job's content back to how it was at the given
+// revision. The job keeps its current status, and the restore goes through
+// the same validation and screening as any other update.
+func (s *JobService) RestoreRevision(ctx context.Context, jobID uuid.UUID, revision int, actorID uuid.UUID) (*domain.Job, error) {
+	rev, err := s.getRevision(ctx, jobID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := rev.Snapshot
+	job := &domain.Job{
+		ID:                  jobID,
+		Title:               snapshot.Title,
+		Description:         snapshot.Description,
+		Location:            snapshot.Location,
+		SalaryRange:         snapshot.SalaryRange,
+		JobType:             snapshot.JobType,
+		ExperienceLevel:     snapshot.ExperienceLevel,
+		Skills:              snapshot.Skills,
+		Openings:            snapshot.Openings,
+		AutoRejectRemaining: snapshot.AutoRejectRemaining,
+		ApplyMethod:         snapshot.ApplyMethod,
+		ApplyURL:            snapshot.ApplyURL,
+		PublishAt:           snapshot.PublishAt,
+		ExpiresAt:           snapshot.ExpiresAt,
+		ApplicationDeadline: snapshot.ApplicationDeadline,
+	}
+
+	if err := s.updateJob(ctx, job, &actorID, domain.RevisionRestored); err != nil {
+		return nil, err
+	}
+	return s.jobRepo.GetByID(ctx, jobID)
+}
+
+// jobFields flattens a job into its JSON fields for comparison.
+func jobFields(job *domain.Job) (map[string]interface{}, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+var ErrInvalidSchedule = errors.New("invalid job schedule")
+
+// validateSchedule checks that a job's publish, expiry and application
+// deadline dates are consistent with each other.
+func validateSchedule(job *domain.Job) error {
+	if job.ExpiresAt != nil {
+		if job.PublishAt != nil && !job.ExpiresAt.After(*job.PublishAt) {
+			return fmt.Errorf("%w: expires_at must be after publish_at", ErrInvalidSchedule)
+		}
+	}
+	if job.ApplicationDeadline != nil {
+		if job.PublishAt != nil && !job.ApplicationDeadline.After(*job.PublishAt) {
+			return fmt.Errorf("%w: application_deadline must be after publish_at", ErrInvalidSchedule)
+		}
+		if job.ExpiresAt != nil && job.ApplicationDeadline.After(*job.ExpiresAt) {
+			return fmt.Errorf("%w: application_deadline must not be after expires_at", ErrInvalidSchedule)
+		}
+	}
+	return nil
+}
+
+// scheduledForLater reports whether a job should stay a draft until its
+// publish time.
+func scheduledForLater(job *domain.Job, now time.Time) bool {
+	return job.PublishAt != nil && job.PublishAt.After(now) && job.PublishedAt == nil
+}
+
+// PublishScheduledJobs moves drafts whose publish time has passed to active,
+// or to the moderation queue when the company requires review. A job that
+// can't be published doesn't hold up the others; their errors are returned
+// together with the number published. Jobs that won't become publishable by
+// waiting, such as duplicates, are taken off the schedule and their
+// recruiters told, so they aren't retried on every run.
+func (s *JobService) PublishScheduledJobs(ctx context.Context, now time.Time) (int, error) {
+	jobs, err := s.jobRepo.ListDueForPublish(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	var errs []error
+	for i := range jobs {
+		job := &jobs[i]
+		err := s.publishScheduledJob(ctx, job)
+		if errors.Is(err, ErrDuplicateJob) || errors.Is(err, ErrInvalidStatusTransition) {
+			err = errors.Join(err, s.unschedule(ctx, job, err))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.ID, err))
+			continue
+		}
+		published++
+	}
+	return published, errors.Join(errs...)
+}
+
+func (s *JobService) publishScheduledJob(ctx context.Context, job *domain.Job) error {
+	status, err := s.resolveStatusChange(ctx, job, domain.JobStatusActive)
+	if err != nil {
+		return err
+	}
+	if err := s.checkPublishDuplicates(ctx, job, job.Status, status); err != nil {
+		return err
+	}
+	return s.setStatus(ctx, job.ID, status, nil)
+}
+
+// unschedule keeps a job that failed to publish as a draft without a publish
+// time and tells its recruiter why.
+func (s *JobService) unschedule(ctx context.Context, job *domain.Job, cause error) error {
+	if err := s.jobRepo.Unschedule(ctx, job.ID); err != nil {
+		return err
+	}
+	return s.notificationService.Notify(ctx, job.CompanyID, domain.NotificationJobNotPublished,
+		"Job not published",
+		fmt.Sprintf("Your job \"%s\" could not be published as scheduled: %v. It was kept as a draft; fix it and publish it with PATCH /api/v1/jobs/%s/status.",
+			job.Title, cause, job.ID),
+		&job.ID)
+}
+
+// ExpireJobs closes active jobs whose expiry time has passed and tells their
+// recruiters. Like PublishScheduledJobs it carries on past jobs that fail.
+func (s *JobService) ExpireJobs(ctx context.Context, now time.Time) (int, error) {
+	jobs, err := s.jobRepo.ListDueForExpiry(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	var errs []error
+	for _, job := range jobs {
+		if err := s.setStatus(ctx, job.ID, domain.JobStatusClosed, nil); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.ID, err))
+			continue
+		}
+		expired++
+
+		err := s.notificationService.Notify(ctx, job.CompanyID, domain.NotificationJobExpired,
+			"Job expired",
+			fmt.Sprintf("Your job \"%s\" has expired and was closed. Renew it with POST /api/v1/jobs/%s/renew.", job.Title, job.ID),
+			&job.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.ID, err))
+		}
+	}
+	return expired, errors.Join(errs...)
+}
+
+// SendExpiryReminders notifies recruiters whose active jobs expire within the
+// configured reminder window. Each job is reminded once per expiry date.
+func (s *JobService) SendExpiryReminders(ctx context.Context, now time.Time) (int, error) {
+	if s.policy.ExpiryReminderDays <= 0 {
+		return 0, nil
+	}
+
+	before := now.AddDate(0, 0, s.policy.ExpiryReminderDays)
+	jobs, err := s.jobRepo.ListExpiringBefore(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, job := range jobs {
+		days := int(job.ExpiresAt.Sub(now).Hours()/24) + 1
+		err := s.notificationService.Notify(ctx, job.CompanyID, domain.NotificationJobExpiring,
+			"Job expiring soon",
+			fmt.Sprintf("Your job \"%s\" expires in %d day(s) on %s. Renew it with POST /api/v1/jobs/%s/renew.",
+				job.Title, days, job.ExpiresAt.Format("2006-01-02"), job.ID),
+			&job.ID)
+		if err == nil {
+			err = s.jobRepo.MarkExpiryReminderSent(ctx, job.ID)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.ID, err))
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
+
+// RenewJob extends a job's expiry by the given number of days, counted from
+// its current expiry or from now if it has already passed. Closed jobs whose
+// expiry had passed are reopened; jobs closed by hand before their expiry
+// stay closed.
+func (s *JobService) RenewJob(ctx context.Context, id uuid.UUID, days int, actorID uuid.UUID) (*domain.Job, error) {
+	if days <= 0 {
+		days = s.policy.RenewalDays
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrJobNotFound
+	}
+
+	now := time.Now()
+	from := now
+	expired := job.ExpiresAt != nil && !job.ExpiresAt.After(now)
+	if job.ExpiresAt != nil && job.ExpiresAt.After(from) {
+		from = *job.ExpiresAt
+	}
+	expiresAt := from.AddDate(0, 0, days)
+	if job.ApplicationDeadline != nil && job.ApplicationDeadline.After(expiresAt) {
+		return nil, fmt.Errorf("%w: application_deadline must not be after expires_at", ErrInvalidSchedule)
+	}
+
+	// The expiry and any reopening are written together, so a renewal that
+	// can't reopen the job doesn't leave it with the new expiry either
+	status := job.Status
+	if job.Status == domain.JobStatusClosed && expired {
+		if status, err = s.resolveStatusChange(ctx, job, domain.JobStatusActive); err != nil {
+			return nil, err
+		}
+		if err := s.checkPublishDuplicates(ctx, job, job.Status, status); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.jobRepo.Renew(ctx, id, expiresAt, status, &actorID); err != nil {
+		return nil, err
+	}
+	return s.jobRepo.GetByID(ctx, id)
+}
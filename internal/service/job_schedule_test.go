@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+)
+
+// scheduleJobRepository keeps jobs in memory for the scheduled publishing
+// path; every other method is left unimplemented.
+type scheduleJobRepository struct {
+	repository.JobRepository
+	jobs map[uuid.UUID]*domain.Job
+}
+
+func (r *scheduleJobRepository) ListDueForPublish(ctx context.Context, now time.Time) ([]domain.Job, error) {
+	var due []domain.Job
+	for _, job := range r.jobs {
+		if job.Status == domain.JobStatusDraft && job.PublishAt != nil && !job.PublishAt.After(now) {
+			due = append(due, *job)
+		}
+	}
+	return due, nil
+}
+
+func (r *scheduleJobRepository) ListDuplicateCandidates(ctx context.Context, companyID *uuid.UUID) ([]domain.Job, error) {
+	var live []domain.Job
+	for _, job := range r.jobs {
+		if job.Status == domain.JobStatusActive {
+			live = append(live, *job)
+		}
+	}
+	return live, nil
+}
+
+func (r *scheduleJobRepository) ChangeJobStatus(ctx context.Context, id uuid.UUID, status string, changedBy *uuid.UUID) error {
+	r.jobs[id].Status = status
+	return nil
+}
+
+func (r *scheduleJobRepository) Unschedule(ctx context.Context, id uuid.UUID) error {
+	r.jobs[id].PublishAt = nil
+	return nil
+}
+
+// notificationLog records every notification sent.
+type notificationLog struct {
+	repository.NotificationRepository
+	sent []domain.Notification
+}
+
+func (r *notificationLog) Create(ctx context.Context, notification *domain.Notification) error {
+	r.sent = append(r.sent, *notification)
+	return nil
+}
+
+func TestPublishScheduledJobsUnschedulesDuplicates(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	due := now.Add(-time.Minute)
+	const description = "We are looking for a backend engineer to design and run payment services in Go " +
+		"with Postgres and Kafka. You will own features end to end and review code."
+
+	live := posting("Backend Engineer", description)
+	live.Status = domain.JobStatusActive
+	duplicate := posting("Backend Engineer", description)
+	duplicate.Status = domain.JobStatusDraft
+	duplicate.PublishAt = &due
+	unique := posting("Night Nurse", "The night ward needs a registered nurse for twelve hour shifts.")
+	unique.Status = domain.JobStatusDraft
+	unique.PublishAt = &due
+
+	repo := &scheduleJobRepository{jobs: map[uuid.UUID]*domain.Job{
+		live.ID: &live, duplicate.ID: &duplicate, unique.ID: &unique,
+	}}
+	notifications := &notificationLog{}
+	s := &JobService{
+		jobRepo:             repo,
+		notificationService: NewNotificationService(notifications),
+		policy: JobPolicy{
+			ModerationMode:     ModerationOff,
+			DuplicatePolicy:    DuplicatePolicyReject,
+			DuplicateThreshold: 0.8,
+		},
+	}
+
+	published, err := s.PublishScheduledJobs(ctx, now)
+	if published != 1 || err == nil {
+		t.Fatalf("first run = %d, %v; want 1 published and the duplicate's error", published, err)
+	}
+	if unique.Status != domain.JobStatusActive {
+		t.Errorf("unique job is %s, want %s", unique.Status, domain.JobStatusActive)
+	}
+	if duplicate.Status != domain.JobStatusDraft || duplicate.PublishAt != nil {
+		t.Errorf("duplicate is %s publishing at %v, want an unscheduled draft", duplicate.Status, duplicate.PublishAt)
+	}
+	if len(notifications.sent) != 1 || notifications.sent[0].Type != domain.NotificationJobNotPublished {
+		t.Fatalf("notifications = %+v, want one %s", notifications.sent, domain.NotificationJobNotPublished)
+	}
+
+	// The duplicate is no longer due, so it isn't retried or reported again
+	published, err = s.PublishScheduledJobs(ctx, now.Add(time.Minute))
+	if published != 0 || err != nil {
+		t.Errorf("second run = %d, %v; want nothing to do", published, err)
+	}
+	if len(notifications.sent) != 1 {
+		t.Errorf("%d notifications after the second run, want 1", len(notifications.sent))
+	}
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/tasks"
+)
+
+var ErrBulkCreateFailed = errors.New("some postings failed validation; no jobs were created")
+
+// BulkCreateResult is stored as the result of a finished bulk create task.
+type BulkCreateResult struct {
+	JobIDs []uuid.UUID `json:"job_ids"`
+}
+
+// BulkStatusResult is stored as the result of a finished bulk status task.
+type BulkStatusResult struct {
+	Updated   int                       `json:"updated"`
+	Unchanged int                       `json:"unchanged"`
+	Failed    int                       `json:"failed"`
+	Jobs      []domain.JobStatusOutcome `json:"jobs"`
+}
+
+// bulkSelectPageSize is the page size used to load the jobs matched by a
+// bulk filter.
+const bulkSelectPageSize = 100
+
+// RunBulkCreate is the task function for bulk creates. Every posting is
+// checked first and each failure is reported against its index; jobs are
+// only created when every posting passes.
+func (s *JobService) RunBulkCreate(ctx context.Context, task *domain.Task, progress *tasks.Progress) (interface{}, error) {
+	var requests []domain.CreateJobRequest
+	if err := json.Unmarshal(task.Payload, &requests); err != nil {
+		return nil, err
+	}
+	companyID := task.OwnerID
+
+	status, err := s.publishStatus(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	// Each posting is checked against the company's live jobs and the
+	// postings earlier in the same batch
+	others, err := s.companySignatures(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, 0, len(requests))
+	for i, req := range requests {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		job := jobFromRequest(companyID, status, req)
+		if err := s.prepareBatchJob(ctx, &job, now, &others); err != nil {
+			progress.Failed(i, req.Title, err)
+			continue
+		}
+		jobs = append(jobs, job)
+		progress.Succeeded()
+	}
+	if task.Failed > 0 {
+		return nil, ErrBulkCreateFailed
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.BulkCreate(ctx, jobs); err != nil {
+		return nil, err
+	}
+	result := BulkCreateResult{JobIDs: make([]uuid.UUID, len(jobs))}
+	for i, job := range jobs {
+		result.JobIDs[i] = job.ID
+	}
+
+	return result, nil
+}
+
+// RunBulkStatus is the task function for bulk status changes.
+func (s *JobService) RunBulkStatus(ctx context.Context, task *domain.Task, progress *tasks.Progress) (interface{}, error) {
+	var req domain.BulkJobStatusRequest
+	if err := json.Unmarshal(task.Payload, &req); err != nil {
+		return nil, err
+	}
+	return s.changeStatuses(ctx, task.OwnerID, req.JobIDs, req.Filter, req.Status, progress)
+}
+
+// RunBulkClose is the task function for bulk closes.
+func (s *JobService) RunBulkClose(ctx context.Context, task *domain.Task, progress *tasks.Progress) (interface{}, error) {
+	var req domain.BulkCloseJobsRequest
+	if err := json.Unmarshal(task.Payload, &req); err != nil {
+		return nil, err
+	}
+	return s.changeStatuses(ctx, task.OwnerID, req.JobIDs, req.Filter, domain.JobStatusClosed, progress)
+}
+
+// changeStatuses moves the selected jobs to status. Jobs that aren't the
+// company's or can't make the transition are reported as failed and left
+// alone; the remaining changes are written in a single transaction, so
+// either all of them apply or none do.
+func (s *JobService) changeStatuses(ctx context.Context, companyID uuid.UUID, ids []uuid.UUID, filter *domain.JobFilter, status string, progress *tasks.Progress) (*BulkStatusResult, error) {
+	ids, jobs, err := s.selectCompanyJobs(ctx, companyID, ids, filter)
+	if err != nil {
+		return nil, err
+	}
+	progress.SetTotal(len(ids))
+
+	result := &BulkStatusResult{Jobs: make([]domain.JobStatusOutcome, len(ids))}
+	var changes []domain.JobStatusChange
+	for i, id := range ids {
+		outcome := &result.Jobs[i]
+		outcome.JobID = id
+
+		job := jobs[id]
+		if job == nil {
+			outcome.Outcome = domain.JobOutcomeFailed
+			outcome.Error = ErrJobNotFound.Error()
+			progress.Failed(i, id.String(), ErrJobNotFound)
+			continue
+		}
+		outcome.From = job.Status
+
+		to, err := s.resolveStatusChange(ctx, job, status)
+		if err == nil {
+			err = s.checkPublishDuplicates(ctx, job, job.Status, to)
+		}
+		if err != nil {
+			outcome.Outcome = domain.JobOutcomeFailed
+			outcome.Error = err.Error()
+			progress.Failed(i, id.String(), err)
+			continue
+		}
+		outcome.To = to
+
+		if to == job.Status {
+			outcome.Outcome = domain.JobOutcomeUnchanged
+			continue
+		}
+		outcome.Outcome = domain.JobOutcomeUpdated
+		changes = append(changes, domain.JobStatusChange{JobID: id, Version: job.Version, Status: to})
+	}
+
+	// Nothing has been written yet, so a cancelled task leaves every job as
+	// it was
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(changes) > 0 {
+		if err := s.jobRepo.ChangeJobStatuses(ctx, changes, &companyID); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, outcome := range result.Jobs {
+		switch outcome.Outcome {
+		case domain.JobOutcomeUpdated:
+			result.Updated++
+			progress.Succeeded()
+		case domain.JobOutcomeUnchanged:
+			result.Unchanged++
+			progress.Succeeded()
+		default:
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// selectCompanyJobs loads the jobs a bulk operation applies to, either the
+// given IDs or every company job matching filter. The IDs are returned in
+// order without repeats; IDs of jobs that don't exist or belong to another
+// company have no entry in the map.
+func (s *JobService) selectCompanyJobs(ctx context.Context, companyID uuid.UUID, ids []uuid.UUID, filter *domain.JobFilter) ([]uuid.UUID, map[uuid.UUID]*domain.Job, error) {
+	jobs := make(map[uuid.UUID]*domain.Job)
+
+	if filter == nil {
+		var unique []uuid.UUID
+		seen := make(map[uuid.UUID]bool)
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			unique = append(unique, id)
+
+			job, err := s.jobRepo.GetByID(ctx, id)
+			if err != nil {
+				return nil, nil, err
+			}
+			if job != nil && job.CompanyID == companyID {
+				jobs[id] = job
+			}
+		}
+		return unique, jobs, nil
+	}
+
+	f := *filter
+	f.CompanyID = &companyID
+	f.OldestFirst = true
+	f.PageSize = bulkSelectPageSize
+	var selected []uuid.UUID
+	for f.Page = 1; ; f.Page++ {
+		page, total, err := s.jobRepo.List(ctx, f)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range page {
+			job := page[i]
+			if jobs[job.ID] == nil {
+				jobs[job.ID] = &job
+				selected = append(selected, job.ID)
+			}
+		}
+		if len(page) < bulkSelectPageSize || f.Page*bulkSelectPageSize >= total {
+			return selected, jobs, nil
+		}
+	}
+}
+
+// ValidateJobStatus reports whether status is a status a job can be in.
+func ValidateJobStatus(status string) error {
+	if _, ok := jobStatusTransitions[status]; !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidJobStatus, status)
+	}
+	return nil
+}
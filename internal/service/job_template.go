@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+)
+
+var (
+	ErrTemplateNotFound    = errors.New("job template not found")
+	ErrInvalidTemplate     = errors.New("job template is missing required fields")
+	ErrMissingPlaceholders = errors.New("missing values for template placeholders")
+)
+
+// placeholderPattern matches {{name}} placeholders in template text.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+type JobTemplateService struct {
+	templateRepo repository.JobTemplateRepository
+	jobRepo      repository.JobRepository
+}
+
+func NewJobTemplateService(templateRepo repository.JobTemplateRepository, jobRepo repository.JobRepository) *JobTemplateService {
+	return &JobTemplateService{
+		templateRepo: templateRepo,
+		jobRepo:      jobRepo,
+	}
+}
+
+// CreateTemplate saves a template for the company, copying the content of
+// req.JobID when it is set.
+func (s *JobTemplateService) CreateTemplate(ctx context.Context, companyID uuid.UUID, req domain.CreateJobTemplateRequest) (*domain.JobTemplate, error) {
+	template := &domain.JobTemplate{
+		ID:              uuid.New(),
+		CompanyID:       companyID,
+		Name:            req.Name,
+		Title:           req.Title,
+		Description:     req.Description,
+		Location:        req.Location,
+		SalaryRange:     req.SalaryRange,
+		JobType:         req.JobType,
+		ExperienceLevel: req.ExperienceLevel,
+		Skills:          req.Skills,
+		Openings:        req.Openings,
+	}
+
+	if req.JobID != nil {
+		job, err := s.jobRepo.GetByID(ctx, *req.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if job == nil || job.CompanyID != companyID {
+			return nil, ErrJobNotFound
+		}
+		template.Title = job.Title
+		template.Description = job.Description
+		template.Location = job.Location
+		template.SalaryRange = job.SalaryRange
+		template.JobType = job.JobType
+		template.ExperienceLevel = job.ExperienceLevel
+		template.Skills = job.Skills
+		template.Openings = job.Openings
+	}
+
+	if template.Title == "" || template.Description == "" || template.Location == "" ||
+		template.JobType == "" || template.ExperienceLevel == "" {
+		return nil, ErrInvalidTemplate
+	}
+	if template.Openings < 1 {
+		template.Openings = 1
+	}
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+	template.Placeholders = templatePlaceholders(template)
+	return template, nil
+}
+
+func (s *JobTemplateService) ListTemplates(ctx context.Context, companyID uuid.UUID) ([]domain.JobTemplate, error) {
+	templates, err := s.templateRepo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range templates {
+		templates[i].Placeholders = templatePlaceholders(&templates[i])
+	}
+	return templates, nil
+}
+
+// GetTemplate returns one of the company's templates. Templates of other
+// companies are reported as not found.
+func (s *JobTemplateService) GetTemplate(ctx context.Context, id, companyID uuid.UUID) (*domain.JobTemplate, error) {
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil || template.CompanyID != companyID {
+		return nil, ErrTemplateNotFound
+	}
+	template.Placeholders = templatePlaceholders(template)
+	return template, nil
+}
+
+func (s *JobTemplateService) DeleteTemplate(ctx context.Context, id, companyID uuid.UUID) error {
+	if _, err := s.GetTemplate(ctx, id, companyID); err != nil {
+		return err
+	}
+	return s.templateRepo.Delete(ctx, id)
+}
+
+// Render fills in a template's placeholders and returns the job it
+// describes. Every placeholder needs a value.
+func (s *JobTemplateService) Render(ctx context.Context, id, companyID uuid.UUID, req domain.JobFromTemplateRequest) (*domain.CreateJobRequest, error) {
+	template, err := s.GetTemplate(ctx, id, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, name := range template.Placeholders {
+		if _, ok := req.Values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingPlaceholders, strings.Join(missing, ", "))
+	}
+
+	fill := func(text string) string {
+		return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+			return req.Values[placeholderPattern.FindStringSubmatch(match)[1]]
+		})
+	}
+
+	job := &domain.CreateJobRequest{
+		Title:               fill(template.Title),
+		Description:         fill(template.Description),
+		Location:            fill(template.Location),
+		JobType:             template.JobType,
+		ExperienceLevel:     template.ExperienceLevel,
+		Openings:            template.Openings,
+		PublishAt:           req.PublishAt,
+		ExpiresAt:           req.ExpiresAt,
+		ApplicationDeadline: req.ApplicationDeadline,
+	}
+	if template.SalaryRange != nil {
+		salary := fill(*template.SalaryRange)
+		job.SalaryRange = &salary
+	}
+	for _, skill := range template.Skills {
+		job.Skills = append(job.Skills, fill(skill))
+	}
+	return job, nil
+}
+
+// templatePlaceholders lists the placeholder names used in a template in the
+// order they first appear.
+func templatePlaceholders(template *domain.JobTemplate) []string {
+	texts := []string{template.Title, template.Description, template.Location}
+	if template.SalaryRange != nil {
+		texts = append(texts, *template.SalaryRange)
+	}
+	texts = append(texts, template.Skills...)
+
+	names := []string{}
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+	"github.com/zahidhasann88/job-board-api/internal/screening"
+)
+
+// statusJobRepository keeps jobs in memory for the edit and status change
+// paths; every other method is left unimplemented.
+type statusJobRepository struct {
+	repository.JobRepository
+	jobs map[uuid.UUID]domain.Job
+}
+
+func (r *statusJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+func (r *statusJobRepository) Update(ctx context.Context, job *domain.Job, changeType string, changedBy *uuid.UUID) error {
+	job.Version++
+	r.jobs[job.ID] = *job
+	return nil
+}
+
+func (r *statusJobRepository) ChangeJobStatus(ctx context.Context, id uuid.UUID, status string, changedBy *uuid.UUID) error {
+	job := r.jobs[id]
+	job.Status = status
+	r.jobs[id] = job
+	return nil
+}
+
+// companyRepository returns the same verified, established company for any
+// ID.
+type companyRepository struct {
+	repository.UserRepository
+}
+
+func (r *companyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return &domain.User{ID: id, Verified: true, CreatedAt: time.Now().AddDate(-1, 0, 0)}, nil
+}
+
+func TestFlaggedEditNeedsReviewToPublish(t *testing.T) {
+	ctx := context.Background()
+	screener := screening.NewPipeline(30, 70, screening.NewKeywordRule([]screening.Pattern{
+		{Expr: regexp.MustCompile(`(?i)\btelegram\b`), Score: 40, Reason: "moves contact to a messaging app"},
+	}))
+	approved := time.Now().AddDate(0, 0, -1)
+
+	tests := []struct {
+		name       string
+		status     string
+		reviewedAt *time.Time
+		edit       string
+		// Status to activate the job with after the edit
+		activate string
+		want     string
+	}{
+		{"clean draft", domain.JobStatusDraft, nil, "Build APIs in Go", domain.JobStatusActive, domain.JobStatusActive},
+		{"flagged draft", domain.JobStatusDraft, nil, "Message us on Telegram", domain.JobStatusActive, domain.JobStatusPendingReview},
+		{"approved draft edited clean", domain.JobStatusDraft, &approved, "Build APIs in Go", domain.JobStatusActive, domain.JobStatusActive},
+		{"approved draft edited flagged", domain.JobStatusDraft, &approved, "Message us on Telegram", domain.JobStatusActive, domain.JobStatusPendingReview},
+		{"approved inactive edited flagged", domain.JobStatusInactive, &approved, "Message us on Telegram", domain.JobStatusActive, domain.JobStatusPendingReview},
+		{"flagged draft published in the edit", domain.JobStatusDraft, nil, "Message us on Telegram", "", domain.JobStatusPendingReview},
+		{"flagged live job", domain.JobStatusActive, &approved, "Message us on Telegram", "", domain.JobStatusPendingReview},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			repo := &statusJobRepository{jobs: map[uuid.UUID]domain.Job{
+				id: {ID: id, CompanyID: uuid.New(), Title: "Engineer", Description: "Build APIs", Status: tt.status, ReviewedAt: tt.reviewedAt, Openings: 1, Version: 1},
+			}}
+			s := &JobService{
+				jobRepo:  repo,
+				userRepo: &companyRepository{},
+				screener: screener,
+				policy:   JobPolicy{ModerationMode: ModerationOff},
+			}
+
+			edit := &domain.Job{ID: id, Title: "Engineer", Description: tt.edit}
+			if tt.activate == "" {
+				edit.Status = domain.JobStatusActive
+			}
+			if err := s.UpdateJob(ctx, edit, uuid.New()); err != nil {
+				t.Fatalf("UpdateJob: %v", err)
+			}
+			if tt.activate != "" {
+				if err := s.ChangeJobStatus(ctx, id, tt.activate, nil); err != nil {
+					t.Fatalf("ChangeJobStatus: %v", err)
+				}
+			}
+
+			if got := repo.jobs[id].Status; got != tt.want {
+				t.Errorf("status = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
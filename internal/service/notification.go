@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+)
+
+type NotificationService struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewNotificationService(notificationRepo repository.NotificationRepository) *NotificationService {
+	return &NotificationService{notificationRepo: notificationRepo}
+}
+
+// Notify stores an in-app notification for the given user.
+func (s *NotificationService) Notify(ctx context.Context, userID uuid.UUID, notificationType, title, message string, jobID *uuid.UUID) error {
+	return s.notificationRepo.Create(ctx, &domain.Notification{
+		ID:      uuid.New(),
+		UserID:  userID,
+		Type:    notificationType,
+		Title:   title,
+		Message: message,
+		JobID:   jobID,
+	})
+}
+
+func (s *NotificationService) List(ctx context.Context, filter domain.NotificationFilter) ([]domain.Notification, int, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 20
+	}
+	return s.notificationRepo.List(ctx, filter)
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, id, userID uuid.UUID) error {
+	return s.notificationRepo.MarkRead(ctx, id, userID)
+}
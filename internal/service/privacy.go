@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+var (
+	ErrInvalidVisibility   = errors.New("profile_visibility must be public, recruiters, applied or hidden")
+	ErrInvalidContactField = errors.New("hidden_contact_fields may only contain phone, email and address")
+	ErrCompanyNotFound     = errors.New("company not found")
+)
+
+func (s *UserService) GetPrivacySettings(ctx context.Context, userID uuid.UUID) (*domain.PrivacySettings, error) {
+	settings, err := s.userRepo.GetPrivacySettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, ErrUserNotFound
+	}
+	return settings, nil
+}
+
+func (s *UserService) UpdatePrivacySettings(ctx context.Context, userID uuid.UUID, req domain.UpdatePrivacySettingsRequest) (*domain.PrivacySettings, error) {
+	settings, err := s.GetPrivacySettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ProfileVisibility != nil {
+		switch *req.ProfileVisibility {
+		case domain.ProfileVisibilityPublic, domain.ProfileVisibilityRecruiters,
+			domain.ProfileVisibilityApplied, domain.ProfileVisibilityHidden:
+		default:
+			return nil, ErrInvalidVisibility
+		}
+		settings.ProfileVisibility = *req.ProfileVisibility
+	}
+	if req.HiddenContactFields != nil {
+		fields := []string{}
+		for _, field := range req.HiddenContactFields {
+			switch field {
+			case domain.ContactFieldPhone, domain.ContactFieldEmail, domain.ContactFieldAddress:
+			default:
+				return nil, ErrInvalidContactField
+			}
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+		settings.HiddenContactFields = fields
+	}
+	if req.OpenToWork != nil {
+		settings.OpenToWork = *req.OpenToWork
+	}
+
+	if err := s.userRepo.UpdatePrivacySettings(ctx, userID, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// BlockCompany hides the job seeker's profile from a company and every other
+// company of the same name, whatever the profile's visibility.
+func (s *UserService) BlockCompany(ctx context.Context, userID, companyID uuid.UUID) (*domain.PrivacySettings, error) {
+	company, err := s.userRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if company == nil || company.Role != domain.RoleRecruiter {
+		return nil, ErrCompanyNotFound
+	}
+	if err := s.userRepo.BlockCompany(ctx, userID, companyID); err != nil {
+		return nil, err
+	}
+	return s.GetPrivacySettings(ctx, userID)
+}
+
+func (s *UserService) UnblockCompany(ctx context.Context, userID, companyID uuid.UUID) (*domain.PrivacySettings, error) {
+	if err := s.userRepo.UnblockCompany(ctx, userID, companyID); err != nil {
+		return nil, err
+	}
+	return s.GetPrivacySettings(ctx, userID)
+}
+
+// viewProfile returns a user's profile as the viewer may see it. Users see
+// their own profile and admins every profile in full. Others only see job
+// seekers' profiles their privacy settings allow, without the contact
+// details they hid; to them a profile they may not see doesn't exist.
+// viewerID is nil for anonymous viewers.
+func (s *UserService) viewProfile(ctx context.Context, viewerID *uuid.UUID, viewerRole domain.UserRole, id uuid.UUID) (*domain.User, error) {
+	profile, err := s.userRepo.GetProfile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, ErrUserNotFound
+	}
+	if viewerID != nil && *viewerID == id || viewerRole == domain.RoleAdmin {
+		return profile, nil
+	}
+
+	if profile.Role == domain.RoleJobSeeker {
+		visible := profile.ProfileVisibility == domain.ProfileVisibilityPublic
+		if viewerID != nil && viewerRole == domain.RoleRecruiter {
+			if visible, err = s.userRepo.VisibleToCompany(ctx, id, *viewerID); err != nil {
+				return nil, err
+			}
+		}
+		if !visible {
+			return nil, ErrUserNotFound
+		}
+	}
+	redactProfile(profile)
+	return profile, nil
+}
+
+// redactProfile removes what only the profile's owner may see: the contact
+// details they hid and their privacy settings.
+func redactProfile(profile *domain.User) {
+	for _, field := range profile.HiddenContactFields {
+		switch field {
+		case domain.ContactFieldEmail:
+			profile.Email = ""
+			if profile.ContactInfo != nil {
+				profile.ContactInfo.Email = nil
+			}
+		case domain.ContactFieldPhone:
+			if profile.ContactInfo != nil {
+				profile.ContactInfo.Phone = nil
+			}
+		case domain.ContactFieldAddress:
+			if profile.ContactInfo != nil {
+				profile.ContactInfo.Address = nil
+			}
+		}
+	}
+	profile.HiddenContactFields = nil
+	profile.ProfileVisibility = ""
+}
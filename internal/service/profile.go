@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/pkg/anonymize"
+)
+
+// GetProfile returns the user's own profile in full.
+func (s *UserService) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	profile, err := s.userRepo.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, ErrUserNotFound
+	}
+	return profile, nil
+}
+
+// ViewProfile returns a user's profile as the viewer may see it and counts
+// the view. viewerID is nil for anonymous viewers, who are told apart by the
+// visitor string instead.
+func (s *UserService) ViewProfile(ctx context.Context, viewerID *uuid.UUID, viewerRole domain.UserRole, visitor string, id uuid.UUID) (*domain.User, error) {
+	profile, err := s.viewProfile(ctx, viewerID, viewerRole, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordProfileView(ctx, viewerID, viewerRole, visitor, id); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// recordProfileView counts a view of the profile, at most once per visitor
+// and day. Users viewing their own profile and admins aren't counted.
+// Visitors are identified the same way as in engagement tracking and only
+// their hash is kept; recruiters' views are attributed to their company.
+func (s *UserService) recordProfileView(ctx context.Context, viewerID *uuid.UUID, viewerRole domain.UserRole, visitor string, id uuid.UUID) error {
+	if viewerID != nil && *viewerID == id || viewerRole == domain.RoleAdmin {
+		return nil
+	}
+
+	view := &domain.ProfileView{
+		UserID:      id,
+		Day:         dayOf(time.Now()),
+		VisitorHash: anonymize.Hash(s.trackingSecret, visitor),
+	}
+	if viewerID != nil && viewerRole == domain.RoleRecruiter {
+		view.CompanyID = viewerID
+	}
+	return s.userRepo.IncrementProfileView(ctx, view)
+}
+
+// GetUserAnalytics reports the user's profile views: the total, the distinct
+// visitors per day between from and to, both inclusive, and the companies
+// that viewed the profile in that range.
+func (s *UserService) GetUserAnalytics(ctx context.Context, userID uuid.UUID, from, to time.Time) (*domain.UserAnalytics, error) {
+	from, to = dayOf(from), dayOf(to)
+	if to.Before(from) || to.Sub(from) >= maxReportDays*24*time.Hour {
+		return nil, ErrInvalidDateRange
+	}
+
+	analytics, err := s.userRepo.GetUserAnalytics(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if analytics == nil {
+		analytics = &domain.UserAnalytics{UserID: userID}
+	}
+
+	days, err := s.userRepo.ProfileViewsByDay(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	views := make(map[string]int, len(days))
+	for _, d := range days {
+		views[d.Date] = d.Views
+	}
+
+	analytics.From = from.Format(time.DateOnly)
+	analytics.To = to.Format(time.DateOnly)
+	analytics.ViewsByDay = []domain.ProfileViewDay{}
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		date := d.Format(time.DateOnly)
+		analytics.ViewsByDay = append(analytics.ViewsByDay, domain.ProfileViewDay{Date: date, Views: views[date]})
+	}
+
+	if analytics.ViewerCompanies, err = s.userRepo.ProfileViewers(ctx, userID, from, to); err != nil {
+		return nil, err
+	}
+	return analytics, nil
+}
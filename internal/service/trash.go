@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrRetentionExpired = errors.New("the restore window for this record has passed")
+)
+
+// retentionCutoff returns the time before which deleted records can no
+// longer be restored and are due for purging.
+func retentionCutoff(now time.Time, days int) time.Time {
+	return now.AddDate(0, 0, -days)
+}
+
+func (s *JobService) DeleteJob(ctx context.Context, id uuid.UUID) error {
+	return s.jobRepo.Delete(ctx, id)
+}
+
+func (s *JobService) ListDeletedJobs(ctx context.Context, companyID uuid.UUID) ([]domain.Job, error) {
+	return s.jobRepo.ListDeleted(ctx, companyID)
+}
+
+// RestoreJob takes one of the company's jobs out of the trash. Jobs of other
+// companies are reported as not found.
+func (s *JobService) RestoreJob(ctx context.Context, id, companyID uuid.UUID) (*domain.Job, error) {
+	job, err := s.jobRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil || job.CompanyID != companyID {
+		return nil, ErrJobNotFound
+	}
+	if job.DeletedAt.Before(retentionCutoff(time.Now(), s.policy.RetentionDays)) {
+		return nil, ErrRetentionExpired
+	}
+
+	if err := s.jobRepo.Restore(ctx, id, &companyID); err != nil {
+		return nil, err
+	}
+	return s.jobRepo.GetByID(ctx, id)
+}
+
+// PurgeDeletedJobs permanently removes jobs that have been in the trash for
+// longer than the retention window.
+func (s *JobService) PurgeDeletedJobs(ctx context.Context, now time.Time) (int, error) {
+	return s.jobRepo.PurgeDeleted(ctx, retentionCutoff(now, s.policy.RetentionDays))
+}
+
+// DeleteUser moves a user and their job postings to the trash.
+func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	return s.userRepo.Delete(ctx, id)
+}
+
+func (s *UserService) RestoreUser(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if user.DeletedAt.Before(retentionCutoff(time.Now(), s.retentionDays)) {
+		return nil, ErrRetentionExpired
+	}
+
+	if err := s.userRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.userRepo.GetByID(ctx, id)
+}
+
+// PurgeDeletedUsers permanently removes users that have been in the trash for
+// longer than the retention window, along with their data.
+func (s *UserService) PurgeDeletedUsers(ctx context.Context, now time.Time) (int, error) {
+	return s.userRepo.PurgeDeleted(ctx, retentionCutoff(now, s.retentionDays))
+}
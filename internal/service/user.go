@@ -10,18 +10,30 @@ import (
 	"github.com/google/uuid"
 	"github.com/zahidhasann88/job-board-api/internal/domain"
 	"github.com/zahidhasann88/job-board-api/internal/repository"
+	"github.com/zahidhasann88/job-board-api/internal/skills"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrEmailTaken   = errors.New("user with this email already exists")
+	ErrEmailDeleted = errors.New("this email belongs to a deleted account; ask an administrator to restore it")
+)
+
 type UserService struct {
-	userRepo  repository.UserRepository
-	jwtSecret string
+	userRepo       repository.UserRepository
+	taxonomy       *skills.Taxonomy
+	jwtSecret      string
+	trackingSecret string
+	retentionDays  int
 }
 
-func NewUserService(userRepo repository.UserRepository, jwtSecret string) *UserService {
+func NewUserService(userRepo repository.UserRepository, taxonomy *skills.Taxonomy, jwtSecret, trackingSecret string, retentionDays int) *UserService {
 	return &UserService{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo:       userRepo,
+		taxonomy:       taxonomy,
+		jwtSecret:      jwtSecret,
+		trackingSecret: trackingSecret,
+		retentionDays:  retentionDays,
 	}
 }
 
@@ -32,7 +44,16 @@ func (s *UserService) CreateUser(ctx context.Context, user *domain.User, passwor
 		return err
 	}
 	if existingUser != nil {
-		return errors.New("user with this email already exists")
+		return ErrEmailTaken
+	}
+	// Deleted users keep their email until they are purged, so registering
+	// it again would only hit the unique constraint
+	deletedUser, err := s.userRepo.GetDeletedByEmail(ctx, user.Email)
+	if err != nil {
+		return err
+	}
+	if deletedUser != nil {
+		return ErrEmailDeleted
 	}
 
 	// Hash password
@@ -96,6 +117,9 @@ func (s *UserService) UpdateProfileDetails(ctx context.Context, userID uuid.UUID
 			return fmt.Errorf("invalid field: %s", key)
 		}
 	}
+	if names, ok := updates["skills"].([]string); ok {
+		updates["skills"] = s.taxonomy.Normalize(names)
+	}
 
 	// Update profile details
 	if err := s.userRepo.UpdateProfileDetails(ctx, userID, updates); err != nil {
@@ -118,3 +142,7 @@ func (s *UserService) UpdateEducationHistory(ctx context.Context, userID uuid.UU
 func (s *UserService) UpdateCertifications(ctx context.Context, userID uuid.UUID, certifications []domain.Certification) error {
 	return s.userRepo.UpdateCertifications(ctx, userID, certifications)
 }
+
+func (s *UserService) SetVerified(ctx context.Context, userID uuid.UUID, verified bool) error {
+	return s.userRepo.SetVerified(ctx, userID, verified)
+}
@@ -0,0 +1,207 @@
+// Package similarity finds jobs like a given one by TF-IDF cosine similarity
+// over their title, description and skills, served from memory.
+package similarity
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/matching"
+	"github.com/zahidhasann88/job-board-api/pkg/textsim"
+)
+
+// Boosts for candidates sharing the job's location or experience level
+const (
+	locationBoost   = 1.2
+	experienceBoost = 1.1
+)
+
+// Term weights: titles and skills say more about a job than its description
+const (
+	titleWeight = 2
+	skillWeight = 2
+)
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"our": true, "the": true, "to": true, "we": true, "will": true, "with": true, "you": true, "your": true,
+}
+
+// Result is a similar job and how similar it is.
+type Result struct {
+	JobID uuid.UUID
+	Score float64
+}
+
+type document struct {
+	terms      map[string]float64
+	location   string
+	experience string
+}
+
+// Index holds the term frequencies of active jobs. Term frequencies are
+// computed when a job is added; inverse document frequencies change with
+// every job and are applied when querying.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[uuid.UUID]*document
+	postings map[string]map[uuid.UUID]float64
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[uuid.UUID]*document),
+		postings: make(map[string]map[uuid.UUID]float64),
+	}
+}
+
+// Put adds or replaces a job. Jobs that aren't active are removed instead,
+// since they are never shown as similar.
+func (ix *Index) Put(job *domain.Job) {
+	if job.Status != domain.JobStatusActive || job.DeletedAt != nil {
+		ix.Remove(job.ID)
+		return
+	}
+
+	doc := &document{
+		terms:      termFrequencies(job),
+		location:   strings.ToLower(strings.TrimSpace(job.Location)),
+		experience: strings.ToLower(job.ExperienceLevel),
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(job.ID)
+	ix.docs[job.ID] = doc
+	for term, tf := range doc.terms {
+		if ix.postings[term] == nil {
+			ix.postings[term] = make(map[uuid.UUID]float64)
+		}
+		ix.postings[term][job.ID] = tf
+	}
+}
+
+func (ix *Index) Remove(id uuid.UUID) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(id)
+}
+
+func (ix *Index) remove(id uuid.UUID) {
+	doc, ok := ix.docs[id]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		delete(ix.postings[term], id)
+		if len(ix.postings[term]) == 0 {
+			delete(ix.postings, term)
+		}
+	}
+	delete(ix.docs, id)
+}
+
+// Similar returns up to limit active jobs most similar to the given one,
+// best first. The job itself must be in the index.
+func (ix *Index) Similar(id uuid.UUID, limit int) []Result {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	doc, ok := ix.docs[id]
+	if !ok {
+		return nil
+	}
+
+	n := float64(len(ix.docs))
+	idf := func(term string) float64 {
+		return math.Log(n/float64(len(ix.postings[term]))) + 1
+	}
+
+	// Dot products with every job sharing a term, then cosine over norms
+	dots := make(map[uuid.UUID]float64)
+	var norm float64
+	for term, tf := range doc.terms {
+		w := tf * idf(term)
+		norm += w * w
+		for other, otherTF := range ix.postings[term] {
+			if other != id {
+				dots[other] += w * otherTF * idf(term)
+			}
+		}
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+
+	results := make([]Result, 0, len(dots))
+	for other, dot := range dots {
+		otherDoc := ix.docs[other]
+		var otherNorm float64
+		for term, tf := range otherDoc.terms {
+			w := tf * idf(term)
+			otherNorm += w * w
+		}
+		score := dot / (norm * math.Sqrt(otherNorm))
+		if doc.location != "" && otherDoc.location == doc.location {
+			score *= locationBoost
+		}
+		if doc.experience != "" && otherDoc.experience == doc.experience {
+			score *= experienceBoost
+		}
+		results = append(results, Result{JobID: other, Score: math.Round(score*1000) / 1000})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].JobID.String() < results[j].JobID.String()
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// termFrequencies weighs the job's terms, each divided by the total so long
+// descriptions don't dominate. Skills are kept apart from words so that the
+// skill "go" doesn't match the word "go".
+func termFrequencies(job *domain.Job) map[string]float64 {
+	counts := make(map[string]float64)
+	for _, w := range words(job.Title) {
+		counts[w] += titleWeight
+	}
+	for _, w := range words(job.Description) {
+		counts[w]++
+	}
+	for _, s := range job.Skills {
+		if s = matching.NormalizeSkill(s); s != "" {
+			counts["skill:"+s] += skillWeight
+		}
+	}
+
+	var total float64
+	for _, c := range counts {
+		total += c
+	}
+	for term := range counts {
+		counts[term] /= total
+	}
+	return counts
+}
+
+func words(text string) []string {
+	var out []string
+	for _, w := range strings.Fields(textsim.Normalize(text)) {
+		if len(w) > 1 && !stopWords[w] {
+			out = append(out, w)
+		}
+	}
+	return out
+}
@@ -0,0 +1,242 @@
+package similarity
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+)
+
+func activeJob(title, description string, skills ...string) *domain.Job {
+	return &domain.Job{
+		ID:              uuid.New(),
+		Title:           title,
+		Description:     description,
+		Skills:          skills,
+		Location:        "Berlin",
+		ExperienceLevel: "mid",
+		Status:          domain.JobStatusActive,
+	}
+}
+
+// checkConsistent checks that the postings are exactly the terms of the
+// indexed documents, with no empty or stale entries left behind.
+func checkConsistent(t *testing.T, ix *Index) {
+	t.Helper()
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	for id, doc := range ix.docs {
+		for term, tf := range doc.terms {
+			if got, ok := ix.postings[term][id]; !ok || got != tf {
+				t.Errorf("posting for %q of job %s = %v, %v; want %v", term, id, got, ok, tf)
+			}
+		}
+	}
+	for term, jobs := range ix.postings {
+		if len(jobs) == 0 {
+			t.Errorf("empty posting list left for %q", term)
+		}
+		for id := range jobs {
+			doc, ok := ix.docs[id]
+			if !ok {
+				t.Errorf("posting for %q refers to job %s, which isn't indexed", term, id)
+				continue
+			}
+			if _, ok := doc.terms[term]; !ok {
+				t.Errorf("posting for %q refers to job %s, which no longer has the term", term, id)
+			}
+		}
+	}
+}
+
+func indexed(ix *Index, id uuid.UUID) bool {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	_, ok := ix.docs[id]
+	return ok
+}
+
+func TestIndexPutRemove(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		// apply changes the index after a and b were put
+		apply       func(ix *Index, a, b *domain.Job)
+		wantA       bool
+		wantB       bool
+		wantPosting map[string]int
+	}{
+		{
+			name:        "put",
+			apply:       func(ix *Index, a, b *domain.Job) {},
+			wantA:       true,
+			wantB:       true,
+			wantPosting: map[string]int{"engineer": 2, "payments": 1, "skill:go": 2, "skill:kafka": 1},
+		},
+		{
+			name:        "remove",
+			apply:       func(ix *Index, a, b *domain.Job) { ix.Remove(a.ID) },
+			wantB:       true,
+			wantPosting: map[string]int{"engineer": 1, "payments": 0, "skill:go": 1, "skill:kafka": 1},
+		},
+		{
+			name: "remove twice",
+			apply: func(ix *Index, a, b *domain.Job) {
+				ix.Remove(a.ID)
+				ix.Remove(a.ID)
+			},
+			wantB:       true,
+			wantPosting: map[string]int{"engineer": 1, "payments": 0},
+		},
+		{
+			name:        "remove unknown job",
+			apply:       func(ix *Index, a, b *domain.Job) { ix.Remove(uuid.New()) },
+			wantA:       true,
+			wantB:       true,
+			wantPosting: map[string]int{"engineer": 2},
+		},
+		{
+			name: "put replaces terms",
+			apply: func(ix *Index, a, b *domain.Job) {
+				edited := *a
+				edited.Title = "Data Analyst"
+				edited.Description = "Dashboards and reporting"
+				edited.Skills = []string{"SQL"}
+				ix.Put(&edited)
+			},
+			wantA:       true,
+			wantB:       true,
+			wantPosting: map[string]int{"engineer": 1, "payments": 0, "analyst": 1, "skill:sql": 1, "skill:go": 1},
+		},
+		{
+			name: "put inactive removes",
+			apply: func(ix *Index, a, b *domain.Job) {
+				closed := *a
+				closed.Status = domain.JobStatusClosed
+				ix.Put(&closed)
+			},
+			wantB:       true,
+			wantPosting: map[string]int{"engineer": 1, "payments": 0},
+		},
+		{
+			name: "put deleted removes",
+			apply: func(ix *Index, a, b *domain.Job) {
+				deleted := *b
+				deleted.DeletedAt = &now
+				ix.Put(&deleted)
+			},
+			wantA:       true,
+			wantPosting: map[string]int{"engineer": 1, "payments": 1, "skill:kafka": 0},
+		},
+		{
+			name: "reactivated job comes back",
+			apply: func(ix *Index, a, b *domain.Job) {
+				closed := *a
+				closed.Status = domain.JobStatusClosed
+				ix.Put(&closed)
+				ix.Put(a)
+			},
+			wantA:       true,
+			wantB:       true,
+			wantPosting: map[string]int{"engineer": 2, "payments": 1},
+		},
+		{
+			name: "remove everything",
+			apply: func(ix *Index, a, b *domain.Job) {
+				ix.Remove(a.ID)
+				ix.Remove(b.ID)
+			},
+			wantPosting: map[string]int{"engineer": 0, "skill:go": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := activeJob("Payments Engineer", "Build payments in Go", "Go")
+			b := activeJob("Platform Engineer", "Run Kafka clusters", "Go", "Kafka")
+			ix := NewIndex()
+			ix.Put(a)
+			ix.Put(b)
+			tt.apply(ix, a, b)
+
+			checkConsistent(t, ix)
+			if got := indexed(ix, a.ID); got != tt.wantA {
+				t.Errorf("a indexed = %v, want %v", got, tt.wantA)
+			}
+			if got := indexed(ix, b.ID); got != tt.wantB {
+				t.Errorf("b indexed = %v, want %v", got, tt.wantB)
+			}
+			for term, want := range tt.wantPosting {
+				if got := len(ix.postings[term]); got != want {
+					t.Errorf("jobs with %q = %d, want %d", term, got, want)
+				}
+			}
+			if !tt.wantA && !tt.wantB && len(ix.postings) != 0 {
+				t.Errorf("postings left in an empty index: %v", ix.postings)
+			}
+		})
+	}
+}
+
+func TestIndexSimilarAfterRemove(t *testing.T) {
+	ix := NewIndex()
+	job := activeJob("Backend Engineer", "Payments services in Go and Postgres", "Go", "PostgreSQL")
+	close1 := activeJob("Backend Engineer", "Billing services in Go and Postgres", "Go", "PostgreSQL")
+	close2 := activeJob("Senior Backend Engineer", "Payments APIs in Go", "Go")
+	unrelated := activeJob("Barista", "Espresso and latte art", "Coffee")
+	for _, j := range []*domain.Job{job, close1, close2, unrelated} {
+		ix.Put(j)
+	}
+
+	results := ix.Similar(job.ID, 10)
+	if len(results) != 2 || results[0].JobID != close1.ID || results[1].JobID != close2.ID {
+		t.Fatalf("Similar = %+v, want %s then %s", results, close1.ID, close2.ID)
+	}
+
+	ix.Remove(close1.ID)
+	results = ix.Similar(job.ID, 10)
+	if len(results) != 1 || results[0].JobID != close2.ID {
+		t.Errorf("Similar after remove = %+v, want only %s", results, close2.ID)
+	}
+
+	if got := ix.Similar(close1.ID, 10); got != nil {
+		t.Errorf("Similar for a removed job = %+v, want nil", got)
+	}
+	if got := ix.Similar(job.ID, 0); len(got) != 0 {
+		t.Errorf("Similar with limit 0 = %+v, want none", got)
+	}
+}
+
+func TestIndexConcurrentPutRemove(t *testing.T) {
+	ix := NewIndex()
+	jobs := make([]*domain.Job, 20)
+	for i := range jobs {
+		jobs[i] = activeJob("Engineer", "Go services and Kafka pipelines", "Go")
+	}
+
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				ix.Put(job)
+				ix.Similar(job.ID, 5)
+				if i%2 == 0 {
+					ix.Remove(job.ID)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	checkConsistent(t, ix)
+	for i, job := range jobs {
+		if got, want := indexed(ix, job.ID), i%2 != 0; got != want {
+			t.Errorf("job %d indexed = %v, want %v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package similarity
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+	"go.uber.org/zap"
+)
+
+// IndexedJobRepository keeps an index in step with the jobs it writes. Reads
+// and writes that don't change a job's content or status pass straight
+// through to the wrapped repository. Once a write has committed it is never
+// reported as failed; if the index can't follow it, the problem is logged.
+type IndexedJobRepository struct {
+	repository.JobRepository
+	index  *Index
+	logger *zap.Logger
+}
+
+func NewIndexedJobRepository(jobs repository.JobRepository, index *Index, logger *zap.Logger) *IndexedJobRepository {
+	return &IndexedJobRepository{JobRepository: jobs, index: index, logger: logger}
+}
+
+// Load indexes every active job. It runs once at startup; afterwards the
+// index is kept up to date by the writes below.
+func (r *IndexedJobRepository) Load(ctx context.Context) error {
+	status := domain.JobStatusActive
+	return r.Stream(ctx, domain.JobFilter{Status: &status}, func(job *domain.Job) error {
+		r.index.Put(job)
+		return nil
+	})
+}
+
+func (r *IndexedJobRepository) Create(ctx context.Context, job *domain.Job) error {
+	if err := r.JobRepository.Create(ctx, job); err != nil {
+		return err
+	}
+	r.index.Put(job)
+	return nil
+}
+
+func (r *IndexedJobRepository) Update(ctx context.Context, job *domain.Job, changeType string, changedBy *uuid.UUID) error {
+	if err := r.JobRepository.Update(ctx, job, changeType, changedBy); err != nil {
+		return err
+	}
+	r.reindex(ctx, job.ID)
+	return nil
+}
+
+func (r *IndexedJobRepository) BulkCreate(ctx context.Context, jobs []domain.Job) error {
+	if err := r.JobRepository.BulkCreate(ctx, jobs); err != nil {
+		return err
+	}
+	for i := range jobs {
+		r.index.Put(&jobs[i])
+	}
+	return nil
+}
+
+func (r *IndexedJobRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.JobRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.index.Remove(id)
+	return nil
+}
+
+func (r *IndexedJobRepository) Restore(ctx context.Context, id uuid.UUID, changedBy *uuid.UUID) error {
+	if err := r.JobRepository.Restore(ctx, id, changedBy); err != nil {
+		return err
+	}
+	r.reindex(ctx, id)
+	return nil
+}
+
+func (r *IndexedJobRepository) ChangeJobStatus(ctx context.Context, id uuid.UUID, status string, changedBy *uuid.UUID) error {
+	if err := r.JobRepository.ChangeJobStatus(ctx, id, status, changedBy); err != nil {
+		return err
+	}
+	r.reindex(ctx, id)
+	return nil
+}
+
+func (r *IndexedJobRepository) ChangeJobStatuses(ctx context.Context, changes []domain.JobStatusChange, changedBy *uuid.UUID) error {
+	if err := r.JobRepository.ChangeJobStatuses(ctx, changes, changedBy); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		r.reindex(ctx, change.JobID)
+	}
+	return nil
+}
+
+func (r *IndexedJobRepository) Renew(ctx context.Context, id uuid.UUID, expiresAt time.Time, status string, changedBy *uuid.UUID) error {
+	if err := r.JobRepository.Renew(ctx, id, expiresAt, status, changedBy); err != nil {
+		return err
+	}
+	r.reindex(ctx, id)
+	return nil
+}
+
+func (r *IndexedJobRepository) ReviewJob(ctx context.Context, id uuid.UUID, status string, reviewerID uuid.UUID, note *string, fraud bool) error {
+	if err := r.JobRepository.ReviewJob(ctx, id, status, reviewerID, note, fraud); err != nil {
+		return err
+	}
+	r.reindex(ctx, id)
+	return nil
+}
+
+// reindex reloads a job after a write that may have changed it only partly.
+// If the job can't be reloaded its entry is dropped rather than left stale;
+// it comes back with the job's next write.
+func (r *IndexedJobRepository) reindex(ctx context.Context, id uuid.UUID) {
+	job, err := r.JobRepository.GetByID(ctx, id)
+	if err != nil {
+		r.logger.Error("failed to reindex job", zap.String("job_id", id.String()), zap.Error(err))
+		r.index.Remove(id)
+		return
+	}
+	if job == nil {
+		r.index.Remove(id)
+		return
+	}
+	r.index.Put(job)
+}
+
+// IndexedUserRepository keeps an index in step with the jobs that are deleted
+// and restored along with their company. Like IndexedJobRepository it logs
+// rather than returns index failures after a committed write.
+type IndexedUserRepository struct {
+	repository.UserRepository
+	jobs   repository.JobRepository
+	index  *Index
+	logger *zap.Logger
+}
+
+func NewIndexedUserRepository(users repository.UserRepository, jobs repository.JobRepository, index *Index, logger *zap.Logger) *IndexedUserRepository {
+	return &IndexedUserRepository{UserRepository: users, jobs: jobs, index: index, logger: logger}
+}
+
+func (r *IndexedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	var ids []uuid.UUID
+	err := r.jobs.Stream(ctx, domain.JobFilter{CompanyID: &id}, func(job *domain.Job) error {
+		ids = append(ids, job.ID)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := r.UserRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	for _, jobID := range ids {
+		r.index.Remove(jobID)
+	}
+	return nil
+}
+
+func (r *IndexedUserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if err := r.UserRepository.Restore(ctx, id); err != nil {
+		return err
+	}
+	status := domain.JobStatusActive
+	err := r.jobs.Stream(ctx, domain.JobFilter{CompanyID: &id, Status: &status}, func(job *domain.Job) error {
+		r.index.Put(job)
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed to reindex restored company's jobs", zap.String("user_id", id.String()), zap.Error(err))
+	}
+	return nil
+}
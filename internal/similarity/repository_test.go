@@ -0,0 +1,248 @@
+package similarity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+	"go.uber.org/zap"
+)
+
+// memoryJobs is an in-memory job store covering the writes the indexed
+// repositories wrap; every other method is left unimplemented.
+type memoryJobs struct {
+	repository.JobRepository
+	jobs map[uuid.UUID]*domain.Job
+	// readErr, when set, fails every GetByID
+	readErr error
+}
+
+func newMemoryJobs(jobs ...*domain.Job) *memoryJobs {
+	m := &memoryJobs{jobs: make(map[uuid.UUID]*domain.Job)}
+	for _, job := range jobs {
+		m.jobs[job.ID] = job
+	}
+	return m
+}
+
+func (m *memoryJobs) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	if m.readErr != nil {
+		return nil, m.readErr
+	}
+	job, ok := m.jobs[id]
+	if !ok || job.DeletedAt != nil {
+		return nil, nil
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (m *memoryJobs) Stream(ctx context.Context, filter domain.JobFilter, fn func(*domain.Job) error) error {
+	for _, job := range m.jobs {
+		if job.DeletedAt != nil ||
+			filter.CompanyID != nil && job.CompanyID != *filter.CompanyID ||
+			filter.Status != nil && job.Status != *filter.Status {
+			continue
+		}
+		copied := *job
+		if err := fn(&copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryJobs) ChangeJobStatus(ctx context.Context, id uuid.UUID, status string, changedBy *uuid.UUID) error {
+	m.jobs[id].Status = status
+	return nil
+}
+
+func (m *memoryJobs) ChangeJobStatuses(ctx context.Context, changes []domain.JobStatusChange, changedBy *uuid.UUID) error {
+	for _, change := range changes {
+		m.jobs[change.JobID].Status = change.Status
+	}
+	return nil
+}
+
+func (m *memoryJobs) Delete(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	m.jobs[id].DeletedAt = &now
+	return nil
+}
+
+func (m *memoryJobs) Restore(ctx context.Context, id uuid.UUID, changedBy *uuid.UUID) error {
+	m.jobs[id].DeletedAt = nil
+	return nil
+}
+
+// memoryUsers deletes a company's live jobs along with it and restores only
+// those, as the postgres repository does.
+type memoryUsers struct {
+	repository.UserRepository
+	jobs    *memoryJobs
+	deleted map[uuid.UUID]*time.Time
+}
+
+func (m *memoryUsers) Delete(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	m.deleted[id] = &now
+	for _, job := range m.jobs.jobs {
+		if job.CompanyID == id && job.DeletedAt == nil {
+			job.DeletedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *memoryUsers) Restore(ctx context.Context, id uuid.UUID) error {
+	for _, job := range m.jobs.jobs {
+		if job.CompanyID == id && job.DeletedAt == m.deleted[id] {
+			job.DeletedAt = nil
+		}
+	}
+	delete(m.deleted, id)
+	return nil
+}
+
+func TestIndexedJobRepository(t *testing.T) {
+	ctx := context.Background()
+	a := activeJob("Backend Engineer", "Payments in Go", "Go")
+	b := activeJob("Backend Engineer", "Billing in Go", "Go")
+	draft := activeJob("Backend Engineer", "Ledger in Go", "Go")
+	draft.Status = domain.JobStatusDraft
+
+	store := newMemoryJobs(a, b, draft)
+	ix := NewIndex()
+	jobs := NewIndexedJobRepository(store, ix, zap.NewNop())
+	if err := jobs.Load(ctx); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	steps := []struct {
+		name  string
+		write func() error
+		want  map[*domain.Job]bool
+	}{
+		{"load indexes active jobs", func() error { return nil },
+			map[*domain.Job]bool{a: true, b: true, draft: false}},
+		{"close", func() error { return jobs.ChangeJobStatus(ctx, a.ID, domain.JobStatusClosed, nil) },
+			map[*domain.Job]bool{a: false, b: true}},
+		{"reopen", func() error { return jobs.ChangeJobStatus(ctx, a.ID, domain.JobStatusActive, nil) },
+			map[*domain.Job]bool{a: true, b: true}},
+		{"publish draft", func() error { return jobs.ChangeJobStatus(ctx, draft.ID, domain.JobStatusActive, nil) },
+			map[*domain.Job]bool{draft: true}},
+		{"delete", func() error { return jobs.Delete(ctx, b.ID) },
+			map[*domain.Job]bool{a: true, b: false}},
+		{"restore", func() error { return jobs.Restore(ctx, b.ID, nil) },
+			map[*domain.Job]bool{a: true, b: true}},
+	}
+	for _, step := range steps {
+		if err := step.write(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		checkConsistent(t, ix)
+		for job, want := range step.want {
+			if got := indexed(ix, job.ID); got != want {
+				t.Errorf("%s: %q indexed = %v, want %v", step.name, job.Description, got, want)
+			}
+		}
+	}
+}
+
+func TestIndexedJobRepositoryReindexError(t *testing.T) {
+	ctx := context.Background()
+	a := activeJob("Backend Engineer", "Payments in Go", "Go")
+	b := activeJob("Backend Engineer", "Billing in Go", "Go")
+
+	store := newMemoryJobs(a, b)
+	ix := NewIndex()
+	jobs := NewIndexedJobRepository(store, ix, zap.NewNop())
+	if err := jobs.Load(ctx); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// The write commits, so it succeeds even though the job can't be
+	// reloaded; the job's stale entry is dropped instead
+	store.readErr = errors.New("connection reset")
+	if err := jobs.ChangeJobStatus(ctx, a.ID, domain.JobStatusClosed, nil); err != nil {
+		t.Fatalf("ChangeJobStatus: %v", err)
+	}
+	err := jobs.ChangeJobStatuses(ctx, []domain.JobStatusChange{{JobID: b.ID, Status: domain.JobStatusClosed}}, nil)
+	if err != nil {
+		t.Fatalf("ChangeJobStatuses: %v", err)
+	}
+	checkConsistent(t, ix)
+	if indexed(ix, a.ID) || indexed(ix, b.ID) {
+		t.Error("jobs that failed to reindex are still indexed")
+	}
+
+	store.readErr = nil
+	if err := jobs.ChangeJobStatus(ctx, a.ID, domain.JobStatusActive, nil); err != nil {
+		t.Fatalf("ChangeJobStatus: %v", err)
+	}
+	if !indexed(ix, a.ID) {
+		t.Error("job is not indexed again after its next write")
+	}
+}
+
+func TestIndexedUserRepository(t *testing.T) {
+	ctx := context.Background()
+	company, other := uuid.New(), uuid.New()
+
+	active := activeJob("Backend Engineer", "Payments in Go", "Go")
+	active.CompanyID = company
+	closed := activeJob("Backend Engineer", "Billing in Go", "Go")
+	closed.CompanyID = company
+	closed.Status = domain.JobStatusClosed
+	deletedEarlier := activeJob("Backend Engineer", "Ledger in Go", "Go")
+	deletedEarlier.CompanyID = company
+	otherJob := activeJob("Backend Engineer", "Payouts in Go", "Go")
+	otherJob.CompanyID = other
+
+	store := newMemoryJobs(active, closed, deletedEarlier, otherJob)
+	ix := NewIndex()
+	jobs := NewIndexedJobRepository(store, ix, zap.NewNop())
+	users := NewIndexedUserRepository(&memoryUsers{jobs: store, deleted: make(map[uuid.UUID]*time.Time)}, store, ix, zap.NewNop())
+	if err := jobs.Load(ctx); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := jobs.Delete(ctx, deletedEarlier.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if err := users.Delete(ctx, company); err != nil {
+		t.Fatalf("Delete user: %v", err)
+	}
+	checkConsistent(t, ix)
+	if indexed(ix, active.ID) {
+		t.Error("job of a deleted company is still indexed")
+	}
+	if !indexed(ix, otherJob.ID) {
+		t.Error("job of another company was removed")
+	}
+	if got := ix.Similar(otherJob.ID, 10); len(got) != 0 {
+		t.Errorf("Similar = %+v, want no jobs of the deleted company", got)
+	}
+
+	if err := users.Restore(ctx, company); err != nil {
+		t.Fatalf("Restore user: %v", err)
+	}
+	checkConsistent(t, ix)
+	for _, tt := range []struct {
+		job  *domain.Job
+		want bool
+	}{
+		{active, true},
+		{closed, false},
+		{deletedEarlier, false},
+		{otherJob, true},
+	} {
+		if got := indexed(ix, tt.job.ID); got != tt.want {
+			t.Errorf("after restore, %q indexed = %v, want %v", tt.job.Description, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Package skills maps the many spellings of a skill to one canonical name and
+// groups skills under parent categories.
+package skills
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Config lists the canonical skills. It is loaded from a JSON file so the
+// taxonomy can grow without a deploy.
+type Config struct {
+	Skills []SkillConfig `json:"skills"`
+}
+
+// SkillConfig is a canonical skill, the other names it goes by and the
+// category it belongs to. Categories are skills themselves and may have
+// parents of their own.
+type SkillConfig struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	Parent  string   `json:"parent,omitempty"`
+}
+
+// DefaultConfig returns the built-in taxonomy used when no taxonomy file is
+// configured.
+func DefaultConfig() *Config {
+	return &Config{Skills: []SkillConfig{
+		{Name: "Backend"},
+		{Name: "Frontend"},
+		{Name: "Mobile"},
+		{Name: "Data"},
+		{Name: "DevOps"},
+		{Name: "Cloud", Parent: "DevOps"},
+		{Name: "Design"},
+
+		{Name: "Go", Aliases: []string{"golang", "go lang"}, Parent: "Backend"},
+		{Name: "Java", Parent: "Backend"},
+		{Name: "Python", Aliases: []string{"py", "python3"}, Parent: "Backend"},
+		{Name: "Ruby", Parent: "Backend"},
+		{Name: "Ruby on Rails", Aliases: []string{"rails", "ror"}, Parent: "Ruby"},
+		{Name: "PHP", Parent: "Backend"},
+		{Name: "C#", Aliases: []string{"csharp", "c sharp"}, Parent: "Backend"},
+		{Name: ".NET", Aliases: []string{"dotnet", "dot net", "asp.net"}, Parent: "Backend"},
+		{Name: "Node.js", Aliases: []string{"node", "nodejs"}, Parent: "Backend"},
+		{Name: "Rust", Parent: "Backend"},
+		{Name: "Django", Parent: "Python"},
+		{Name: "Spring", Aliases: []string{"spring boot", "springboot"}, Parent: "Java"},
+
+		{Name: "JavaScript", Aliases: []string{"js", "ecmascript"}, Parent: "Frontend"},
+		{Name: "TypeScript", Aliases: []string{"ts"}, Parent: "Frontend"},
+		{Name: "React", Aliases: []string{"reactjs", "react.js"}, Parent: "Frontend"},
+		{Name: "Vue", Aliases: []string{"vuejs", "vue.js"}, Parent: "Frontend"},
+		{Name: "Angular", Aliases: []string{"angularjs"}, Parent: "Frontend"},
+		{Name: "HTML", Aliases: []string{"html5"}, Parent: "Frontend"},
+		{Name: "CSS", Aliases: []string{"css3"}, Parent: "Frontend"},
+
+		{Name: "Swift", Parent: "Mobile"},
+		{Name: "Kotlin", Parent: "Mobile"},
+		{Name: "Flutter", Parent: "Mobile"},
+		{Name: "React Native", Aliases: []string{"reactnative"}, Parent: "Mobile"},
+
+		{Name: "SQL", Parent: "Data"},
+		{Name: "PostgreSQL", Aliases: []string{"postgres", "psql"}, Parent: "Data"},
+		{Name: "MySQL", Parent: "Data"},
+		{Name: "MongoDB", Aliases: []string{"mongo"}, Parent: "Data"},
+		{Name: "Redis", Parent: "Data"},
+		{Name: "Machine Learning", Aliases: []string{"ml"}, Parent: "Data"},
+
+		{Name: "Docker", Parent: "DevOps"},
+		{Name: "Kubernetes", Aliases: []string{"k8s", "kube"}, Parent: "DevOps"},
+		{Name: "Terraform", Parent: "DevOps"},
+		{Name: "AWS", Aliases: []string{"amazon web services"}, Parent: "Cloud"},
+		{Name: "GCP", Aliases: []string{"google cloud", "google cloud platform"}, Parent: "Cloud"},
+		{Name: "Azure", Aliases: []string{"microsoft azure"}, Parent: "Cloud"},
+
+		{Name: "Figma", Parent: "Design"},
+		{Name: "UX", Aliases: []string{"ux design", "user experience"}, Parent: "Design"},
+	}}
+}
+
+// LoadConfig reads a taxonomy file. An empty path returns DefaultConfig.
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		return DefaultConfig(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read skill taxonomy: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse skill taxonomy: %w", err)
+	}
+	return cfg, nil
+}
+
+// Build checks the taxonomy and indexes it for lookups. Every name and alias
+// must be unique once keyed, and parents must be skills of the taxonomy
+// without forming a cycle.
+func (cfg *Config) Build() (*Taxonomy, error) {
+	t := &Taxonomy{
+		skills: make(map[string]*Skill, len(cfg.Skills)),
+		names:  make(map[string]string),
+	}
+	for _, sc := range cfg.Skills {
+		key := Key(sc.Name)
+		if key == "" {
+			return nil, fmt.Errorf("skill taxonomy: skill without a name")
+		}
+		if _, ok := t.skills[key]; ok {
+			return nil, fmt.Errorf("skill taxonomy: duplicate skill %q", sc.Name)
+		}
+		t.skills[key] = &Skill{Name: strings.TrimSpace(sc.Name), Aliases: sc.Aliases}
+	}
+
+	for _, sc := range cfg.Skills {
+		skill := t.skills[Key(sc.Name)]
+		for _, name := range append([]string{sc.Name}, sc.Aliases...) {
+			key := Key(name)
+			if other, ok := t.names[key]; ok && other != skill.Name {
+				return nil, fmt.Errorf("skill taxonomy: %q names both %s and %s", name, other, skill.Name)
+			}
+			t.names[key] = skill.Name
+		}
+		if sc.Parent != "" {
+			parent, ok := t.skills[Key(sc.Parent)]
+			if !ok {
+				return nil, fmt.Errorf("skill taxonomy: unknown parent %q of %s", sc.Parent, sc.Name)
+			}
+			skill.Parent = parent.Name
+		}
+	}
+
+	for _, skill := range t.skills {
+		seen := map[string]bool{skill.Name: true}
+		for parent := skill.Parent; parent != ""; parent = t.skills[Key(parent)].Parent {
+			if seen[parent] {
+				return nil, fmt.Errorf("skill taxonomy: %s is its own ancestor", skill.Name)
+			}
+			seen[parent] = true
+		}
+	}
+
+	t.sorted = make([]*Skill, 0, len(t.skills))
+	for _, skill := range t.skills {
+		t.sorted = append(t.sorted, skill)
+	}
+	sort.Slice(t.sorted, func(i, j int) bool { return t.sorted[i].Name < t.sorted[j].Name })
+	return t, nil
+}
+
+// Skill is a canonical skill of the taxonomy.
+type Skill struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	Parent  string   `json:"parent,omitempty"`
+}
+
+// Taxonomy looks up skills by any of their names. It is read-only once built
+// and safe for concurrent use.
+type Taxonomy struct {
+	skills map[string]*Skill
+	// Canonical name by the key of every name and alias
+	names  map[string]string
+	sorted []*Skill
+}
+
+// Key reduces a skill name to the form names are compared in: lowercase,
+// without spaces, dots, dashes or underscores. "Node.js" and "nodejs" have
+// the same key.
+func Key(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch r {
+		case ' ', '.', '-', '_':
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Canonical returns the taxonomy's name for a skill. Skills the taxonomy
+// doesn't know are kept, with surrounding and repeated spaces removed.
+func (t *Taxonomy) Canonical(name string) string {
+	if canonical, ok := t.names[Key(name)]; ok {
+		return canonical
+	}
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Normalize maps each skill to its canonical name, dropping blanks and
+// duplicates while keeping the original order.
+func (t *Taxonomy) Normalize(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	out := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		canonical := t.Canonical(name)
+		if canonical == "" || seen[Key(canonical)] {
+			continue
+		}
+		seen[Key(canonical)] = true
+		out = append(out, canonical)
+	}
+	return out
+}
+
+// Expand normalizes the skills and adds every skill filed under them, so a
+// search for Backend also finds Go and Django.
+func (t *Taxonomy) Expand(names []string) []string {
+	out := t.Normalize(names)
+	seen := make(map[string]bool, len(out))
+	for _, name := range out {
+		seen[name] = true
+	}
+	for i := 0; i < len(out); i++ {
+		for _, skill := range t.sorted {
+			if skill.Parent == out[i] && !seen[skill.Name] {
+				seen[skill.Name] = true
+				out = append(out, skill.Name)
+			}
+		}
+	}
+	return out
+}
+
+// Suggest returns up to limit skills whose name or an alias starts with the
+// prefix, for autocomplete. Skills whose name matches come before those
+// matched by an alias, each alphabetically.
+func (t *Taxonomy) Suggest(prefix string, limit int) []Skill {
+	key := Key(prefix)
+	if key == "" {
+		return []Skill{}
+	}
+
+	byName, byAlias := []Skill{}, []Skill{}
+	for _, skill := range t.sorted {
+		if strings.HasPrefix(Key(skill.Name), key) {
+			byName = append(byName, *skill)
+			continue
+		}
+		for _, alias := range skill.Aliases {
+			if strings.HasPrefix(Key(alias), key) {
+				byAlias = append(byAlias, *skill)
+				break
+			}
+		}
+	}
+	suggestions := append(byName, byAlias...)
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
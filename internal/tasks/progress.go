@@ -0,0 +1,68 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	flushEvery    = 25
+	flushInterval = time.Second
+)
+
+// Progress records a running task's per-item outcomes. Updates are written
+// to the task record in batches so that polling clients see them without a
+// write per item.
+type Progress struct {
+	repo      repository.TaskRepository
+	logger    *zap.Logger
+	task      *domain.Task
+	pending   int
+	lastFlush time.Time
+}
+
+// SetTotal sets the number of items once a task has worked it out, for
+// tasks submitted before it was known.
+func (p *Progress) SetTotal(total int) {
+	p.task.Total = total
+	p.flush()
+}
+
+// Succeeded records that one more item was processed.
+func (p *Progress) Succeeded() {
+	p.task.Processed++
+	p.changed()
+}
+
+// Failed records that the item at index could not be processed. Ref
+// identifies the item, such as a job ID, and may be empty.
+func (p *Progress) Failed(index int, ref string, err error) {
+	p.task.Processed++
+	p.task.Failed++
+	p.task.Errors = append(p.task.Errors, domain.TaskItemError{
+		Index:   index,
+		Ref:     ref,
+		Message: err.Error(),
+	})
+	p.changed()
+}
+
+func (p *Progress) changed() {
+	p.pending++
+	if p.pending >= flushEvery || time.Since(p.lastFlush) >= flushInterval {
+		p.flush()
+	}
+}
+
+func (p *Progress) flush() {
+	t := p.task
+	if err := p.repo.UpdateProgress(context.Background(), t.ID, t.Total, t.Processed, t.Failed, t.Errors); err != nil {
+		p.logger.Error("failed to save task progress", zap.Error(err))
+	}
+	p.pending = 0
+	p.lastFlush = time.Now()
+}
@@ -0,0 +1,264 @@
+// Package tasks runs long operations in the background. Every task is
+// persisted so that clients can poll its progress and cancel it.
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/zahidhasann88/job-board-api/internal/domain"
+	"github.com/zahidhasann88/job-board-api/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTaskNotFound    = errors.New("task not found")
+	ErrTaskFinished    = errors.New("task has already finished")
+	ErrUnknownTaskType = errors.New("unknown task type")
+)
+
+// Func runs a task. It reports per-item outcomes through progress and
+// should stop early once ctx is cancelled. The returned value is stored as
+// the task's result.
+type Func func(ctx context.Context, task *domain.Task, progress *Progress) (interface{}, error)
+
+// Runner executes queued tasks on a fixed pool of workers.
+type Runner struct {
+	repo    repository.TaskRepository
+	logger  *zap.Logger
+	workers int
+	funcs   map[string]Func
+	queue   chan uuid.UUID
+
+	ctx     context.Context
+	mu      sync.Mutex
+	cancels map[uuid.UUID]context.CancelFunc
+}
+
+func NewRunner(repo repository.TaskRepository, logger *zap.Logger, workers int) *Runner {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Runner{
+		repo:    repo,
+		logger:  logger,
+		workers: workers,
+		funcs:   make(map[string]Func),
+		queue:   make(chan uuid.UUID, 256),
+		ctx:     context.Background(),
+		cancels: make(map[uuid.UUID]context.CancelFunc),
+	}
+}
+
+// Register sets the function that runs tasks of the given type. It must be
+// called before Start.
+func (r *Runner) Register(taskType string, fn Func) {
+	r.funcs[taskType] = fn
+}
+
+// Start runs the workers in the background until ctx is cancelled. Tasks
+// left running by a previous process are marked failed and tasks still
+// queued are picked up again.
+func (r *Runner) Start(ctx context.Context) error {
+	r.ctx = ctx
+
+	if n, err := r.repo.FailInterrupted(ctx); err != nil {
+		return err
+	} else if n > 0 {
+		r.logger.Warn("marked interrupted tasks as failed", zap.Int("count", n))
+	}
+
+	queued, err := r.repo.ListQueued(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < r.workers; i++ {
+		go r.work()
+	}
+	for _, id := range queued {
+		r.enqueue(id)
+	}
+	return nil
+}
+
+// Submit records a new task and queues it for execution. The payload is
+// stored with the task and handed to its Func.
+func (r *Runner) Submit(ctx context.Context, ownerID uuid.UUID, taskType string, total int, payload interface{}) (*domain.Task, error) {
+	if _, ok := r.funcs[taskType]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTaskType, taskType)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	task := &domain.Task{
+		ID:      uuid.New(),
+		OwnerID: ownerID,
+		Type:    taskType,
+		Status:  domain.TaskQueued,
+		Total:   total,
+		Payload: data,
+	}
+	if err := r.repo.Create(ctx, task); err != nil {
+		return nil, err
+	}
+
+	r.enqueue(task.ID)
+	return task, nil
+}
+
+// Get returns a task owned by ownerID. Tasks owned by someone else are
+// reported as not found.
+func (r *Runner) Get(ctx context.Context, id, ownerID uuid.UUID) (*domain.Task, error) {
+	task, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil || task.OwnerID != ownerID {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// Cancel stops a queued or running task. Work already done by a running task
+// is kept; it stops before its next item.
+func (r *Runner) Cancel(ctx context.Context, id, ownerID uuid.UUID) (*domain.Task, error) {
+	task, err := r.Get(ctx, id, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Finished() {
+		return nil, ErrTaskFinished
+	}
+
+	if err := r.repo.RequestCancel(ctx, id); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if cancel, ok := r.cancels[id]; ok {
+		cancel()
+	}
+	r.mu.Unlock()
+
+	return r.repo.GetByID(ctx, id)
+}
+
+func (r *Runner) enqueue(id uuid.UUID) {
+	select {
+	case r.queue <- id:
+	default:
+		// The queue is full; wait for a free slot without blocking the caller
+		go func() {
+			select {
+			case r.queue <- id:
+			case <-r.ctx.Done():
+			}
+		}()
+	}
+}
+
+func (r *Runner) work() {
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case id := <-r.queue:
+			r.run(id)
+		}
+	}
+}
+
+func (r *Runner) run(id uuid.UUID) {
+	logger := r.logger.With(zap.String("task_id", id.String()))
+
+	started, err := r.repo.Start(r.ctx, id)
+	if err != nil {
+		logger.Error("failed to start task", zap.Error(err))
+		return
+	}
+	if !started {
+		// Cancelled while it was still queued
+		return
+	}
+
+	task, err := r.repo.GetByID(r.ctx, id)
+	if err != nil || task == nil {
+		logger.Error("failed to load task", zap.Error(err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	defer cancel()
+	r.mu.Lock()
+	r.cancels[id] = cancel
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.cancels, id)
+		r.mu.Unlock()
+	}()
+
+	// A cancel request may have arrived between Start and registering the
+	// cancel function above
+	if task.CancelRequested {
+		cancel()
+	}
+
+	progress := &Progress{repo: r.repo, logger: logger, task: task}
+	result, err := r.call(ctx, task, progress)
+	progress.flush()
+
+	status := domain.TaskSucceeded
+	var taskErr *string
+	switch {
+	case ctx.Err() != nil && r.ctx.Err() == nil:
+		status = domain.TaskCancelled
+	case err != nil:
+		status = domain.TaskFailed
+		msg := err.Error()
+		taskErr = &msg
+	}
+
+	var data []byte
+	if result != nil {
+		if data, err = json.Marshal(result); err != nil {
+			logger.Error("failed to encode task result", zap.Error(err))
+			data = nil
+		}
+	}
+
+	// The runner's context may already be done during shutdown
+	if err := r.repo.Finish(context.Background(), id, status, data, taskErr); err != nil {
+		logger.Error("failed to finish task", zap.Error(err))
+		return
+	}
+	logger.Info("task finished",
+		zap.String("type", task.Type),
+		zap.String("status", status),
+		zap.Int("processed", task.Processed),
+		zap.Int("failed", task.Failed),
+	)
+}
+
+// call runs the task's Func, turning a panic into a task failure.
+func (r *Runner) call(ctx context.Context, task *domain.Task, progress *Progress) (result interface{}, err error) {
+	fn, ok := r.funcs[task.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTaskType, task.Type)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("task panicked: %v", p)
+		}
+	}()
+	return fn(ctx, task, progress)
+}
@@ -0,0 +1,16 @@
+// Package anonymize turns visitor identifiers into stable pseudonyms so that
+// repeat visits can be counted without storing who the visitor was.
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Hash returns a hex HMAC-SHA256 of id keyed with secret.
+func Hash(secret, id string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
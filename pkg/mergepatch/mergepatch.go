@@ -0,0 +1,48 @@
+// Package mergepatch implements JSON Merge Patch as described in RFC 7396.
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidPatch = errors.New("merge patch must be a JSON document")
+
+// Apply merges patch into the JSON document target and returns the result.
+// Objects in the patch are merged recursively, null removes a member and any
+// other value replaces the target's value outright.
+func Apply(target, patch []byte) ([]byte, error) {
+	var patchValue interface{}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, ErrInvalidPatch
+	}
+
+	var targetValue interface{}
+	if len(target) > 0 {
+		if err := json.Unmarshal(target, &targetValue); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(merge(targetValue, patchValue))
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = merge(targetObject[name], value)
+	}
+	return targetObject
+}
@@ -0,0 +1,101 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		// The examples from RFC 7396, Appendix A
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null deletes member", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"null deletes one of several", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"array replaces string", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"string replaces array", `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{"nested merge and delete", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"arrays aren't merged", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{"array replaces array", `["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{"array replaces object", `{"a":"b"}`, `["c"]`, `["c"]`},
+		{"null patch replaces document", `{"a":"foo"}`, `null`, `null`},
+		{"string patch replaces document", `{"a":"foo"}`, `"bar"`, `"bar"`},
+		{"null member stays out", `{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{"object replaces array", `[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{"nested null never added", `{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+
+		// Further cases
+		{"empty patch changes nothing", `{"a":{"b":1},"c":[1]}`, `{}`, `{"a":{"b":1},"c":[1]}`},
+		{"deleting a missing member", `{"a":1}`, `{"b":null}`, `{"a":1}`},
+		{"deep nested merge", `{"a":{"b":{"c":1,"d":2}},"e":3}`, `{"a":{"b":{"c":null,"f":4}}}`, `{"a":{"b":{"d":2,"f":4}},"e":3}`},
+		{"object merges onto scalar", `{"a":1}`, `{"a":{"b":null,"c":2}}`, `{"a":{"c":2}}`},
+		{"empty target", ``, `{"a":1,"b":null}`, `{"a":1}`},
+		{"number patch", `{"a":1}`, `42`, `42`},
+		{"boolean patch", `{"a":1}`, `true`, `true`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.target), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if !equalJSON(t, got, []byte(tt.want)) {
+				t.Errorf("Apply(%s, %s) = %s, want %s", tt.target, tt.patch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		patch   string
+		wantErr error
+	}{
+		{"empty patch", `{"a":1}`, ``, ErrInvalidPatch},
+		{"malformed patch", `{"a":1}`, `{"a":`, ErrInvalidPatch},
+		{"trailing garbage", `{"a":1}`, `{} x`, ErrInvalidPatch},
+		{"malformed target", `{"a":`, `{"a":1}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Apply([]byte(tt.target), []byte(tt.patch))
+			if err == nil {
+				t.Fatal("Apply succeeded, want an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Apply error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyLeavesTargetUnchanged(t *testing.T) {
+	target := []byte(`{"a":{"b":1}}`)
+	if _, err := Apply(target, []byte(`{"a":{"b":null}}`)); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if string(target) != `{"a":{"b":1}}` {
+		t.Errorf("target modified to %s", target)
+	}
+}
+
+func equalJSON(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var av, bv interface{}
+	if err := json.Unmarshal(a, &av); err != nil {
+		t.Fatalf("invalid JSON %s: %v", a, err)
+	}
+	if err := json.Unmarshal(b, &bv); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	return reflect.DeepEqual(av, bv)
+}
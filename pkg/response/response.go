@@ -41,3 +41,12 @@ func Error(c *gin.Context, status int, message string, err string) {
 		Error:   err,
 	})
 }
+
+func ErrorWithData(c *gin.Context, status int, message string, err string, data interface{}) {
+	c.JSON(status, Response{
+		Status:  status,
+		Message: message,
+		Data:    data,
+		Error:   err,
+	})
+}
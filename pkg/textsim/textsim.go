@@ -0,0 +1,135 @@
+package textsim
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Normalize lowercases text, replaces punctuation with spaces and collapses
+// runs of whitespace so that formatting differences don't affect comparisons.
+func Normalize(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	space := true
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		if !space {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Tokens returns the words of the normalized text.
+func Tokens(text string) []string {
+	return strings.Fields(Normalize(text))
+}
+
+// Shingles returns the set of k-word shingles of the normalized text. Texts
+// shorter than k words yield a single shingle containing every word.
+func Shingles(text string, k int) map[string]struct{} {
+	words := Tokens(text)
+	set := make(map[string]struct{})
+	if len(words) == 0 {
+		return set
+	}
+	if len(words) <= k {
+		set[strings.Join(words, " ")] = struct{}{}
+		return set
+	}
+	for i := 0; i+k <= len(words); i++ {
+		set[strings.Join(words[i:i+k], " ")] = struct{}{}
+	}
+	return set
+}
+
+// Jaccard returns the Jaccard similarity of two shingle sets.
+func Jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	intersection := 0
+	for s := range a {
+		if _, ok := b[s]; ok {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
+
+// MinHash computes a signature of n minimum hash values over the shingle set.
+// The fraction of equal positions in two signatures estimates the Jaccard
+// similarity of the underlying sets.
+func MinHash(shingles map[string]struct{}, n int) []uint64 {
+	sig := make([]uint64, n)
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+	for s := range shingles {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		base := h.Sum64()
+		for i := range sig {
+			if v := splitmix64(base ^ splitmix64(uint64(i))); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	return sig
+}
+
+// SignatureSimilarity estimates the Jaccard similarity of two MinHash
+// signatures of equal length.
+func SignatureSimilarity(a, b []uint64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] && a[i] != math.MaxUint64 {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// BandKeys splits a signature into bands and hashes each band, for
+// locality-sensitive bucketing: similar signatures share at least one key
+// with high probability.
+func BandKeys(sig []uint64, bands int) []uint64 {
+	if bands <= 0 || len(sig) < bands {
+		return nil
+	}
+	rows := len(sig) / bands
+	keys := make([]uint64, bands)
+	buf := make([]byte, 8)
+	for b := 0; b < bands; b++ {
+		h := fnv.New64a()
+		binary.LittleEndian.PutUint64(buf, uint64(b))
+		h.Write(buf)
+		for _, v := range sig[b*rows : (b+1)*rows] {
+			binary.LittleEndian.PutUint64(buf, v)
+			h.Write(buf)
+		}
+		keys[b] = h.Sum64()
+	}
+	return keys
+}
+
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
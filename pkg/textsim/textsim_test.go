@@ -0,0 +1,191 @@
+package textsim
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", ""},
+		{"lowercases", "Senior GO Engineer", "senior go engineer"},
+		{"punctuation becomes space", "C++/Go, remote!", "c go remote"},
+		{"collapses whitespace", "  a \t\n b  ", "a b"},
+		{"keeps digits and letters", "5+ years, ÄÖ", "5 years äö"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.in); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShingles(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		k    int
+		want []string
+	}{
+		{"empty", "", 3, nil},
+		{"only punctuation", "!!! ...", 3, nil},
+		{"shorter than k", "Go developer", 3, []string{"go developer"}},
+		{"exactly k", "senior go developer", 3, []string{"senior go developer"}},
+		{"sliding window", "a b c d", 2, []string{"a b", "b c", "c d"}},
+		{"repeats collapse", "a b a b a b", 2, []string{"a b", "b a"}},
+		{"formatting ignored", "A, B. C!", 2, []string{"a b", "b c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Sorted(maps.Keys(Shingles(tt.text, tt.k)))
+			want := slices.Sorted(slices.Values(tt.want))
+			if !slices.Equal(got, want) {
+				t.Errorf("Shingles(%q, %d) = %q, want %q", tt.text, tt.k, got, want)
+			}
+		})
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want float64
+	}{
+		{"both empty", nil, nil, 0},
+		{"one empty", []string{"x"}, nil, 0},
+		{"identical", []string{"x", "y"}, []string{"x", "y"}, 1},
+		{"disjoint", []string{"x"}, []string{"y"}, 0},
+		{"half", []string{"x", "y"}, []string{"y", "z"}, 1.0 / 3},
+		{"subset", []string{"x"}, []string{"x", "y", "z", "w"}, 0.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Jaccard(set(tt.a...), set(tt.b...)); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Jaccard = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinHashEstimatesJaccard(t *testing.T) {
+	const n = 256
+	base := "we are hiring a senior backend engineer to build payment services in go " +
+		"with postgres kafka and kubernetes on a small product team in berlin"
+
+	tests := []struct {
+		name  string
+		other string
+	}{
+		{"identical", base},
+		{"reformatted", "We are HIRING a Senior Backend Engineer, to build payment services in Go; " +
+			"with Postgres, Kafka and Kubernetes on a small product team in Berlin!"},
+		{"small edit", base + " remote friendly"},
+		{"half rewritten", "we are hiring a senior backend engineer to build payment services in go " +
+			"for our mobile app using rust redis and terraform across three time zones"},
+		{"unrelated", "part time barista wanted for a busy coffee shop weekend shifts and tips"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := Shingles(base, 3), Shingles(tt.other, 3)
+			want := Jaccard(a, b)
+			got := SignatureSimilarity(MinHash(a, n), MinHash(b, n))
+			if math.Abs(got-want) > 0.15 {
+				t.Errorf("signature similarity %v too far from Jaccard %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMinHashDeterministic(t *testing.T) {
+	s := Shingles("one two three four five", 2)
+	a, b := MinHash(s, 64), MinHash(s, 64)
+	if !slices.Equal(a, b) {
+		t.Fatal("MinHash of the same set differs between calls")
+	}
+	if len(a) != 64 {
+		t.Fatalf("len(MinHash) = %d, want 64", len(a))
+	}
+}
+
+func TestSignatureSimilarity(t *testing.T) {
+	empty := MinHash(nil, 4)
+	tests := []struct {
+		name string
+		a, b []uint64
+		want float64
+	}{
+		{"no signature", nil, nil, 0},
+		{"different lengths", []uint64{1, 2}, []uint64{1}, 0},
+		{"equal", []uint64{1, 2, 3, 4}, []uint64{1, 2, 3, 4}, 1},
+		{"half equal", []uint64{1, 2, 3, 4}, []uint64{1, 2, 5, 6}, 0.5},
+		{"empty sets never match", empty, empty, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SignatureSimilarity(tt.a, tt.b); got != tt.want {
+				t.Errorf("SignatureSimilarity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBandKeys(t *testing.T) {
+	sig := []uint64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	tests := []struct {
+		name  string
+		sig   []uint64
+		bands int
+		want  int
+	}{
+		{"no bands", sig, 0, 0},
+		{"negative bands", sig, -1, 0},
+		{"more bands than rows", sig[:3], 4, 0},
+		{"one band", sig, 1, 1},
+		{"even split", sig, 4, 4},
+		{"leftover rows ignored", sig[:7], 3, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BandKeys(tt.sig, tt.bands); len(got) != tt.want {
+				t.Errorf("len(BandKeys) = %d, want %d", len(got), tt.want)
+			}
+		})
+	}
+
+	t.Run("only the changed band differs", func(t *testing.T) {
+		changed := append([]uint64(nil), sig...)
+		changed[5] = 99 // third band of four, rows 4 and 5
+
+		a, b := BandKeys(sig, 4), BandKeys(changed, 4)
+		for i := range a {
+			if same := a[i] == b[i]; same != (i != 2) {
+				t.Errorf("band %d: keys equal = %v", i, same)
+			}
+		}
+	})
+
+	t.Run("bands with equal rows get different keys", func(t *testing.T) {
+		keys := BandKeys([]uint64{7, 7, 7, 7}, 2)
+		if keys[0] == keys[1] {
+			t.Error("band index isn't part of the key")
+		}
+	})
+}
+
+func set(items ...string) map[string]struct{} {
+	s := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+	return s
+}
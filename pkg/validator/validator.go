@@ -176,10 +176,11 @@ func validateExperienceLevel(fl validator.FieldLevel) bool {
 
 func validateApplicationStatus(fl validator.FieldLevel) bool {
 	validStatus := map[string]bool{
-		"pending":  true,
-		"reviewed": true,
-		"accepted": true,
-		"rejected": true,
+		"pending":     true,
+		"reviewed":    true,
+		"interviewed": true,
+		"accepted":    true,
+		"rejected":    true,
 	}
 	return validStatus[strings.ToLower(fl.Field().String())]
 }
@@ -299,7 +300,7 @@ func getErrorMessage(err validator.FieldError) string {
 	case "experience_level":
 		return "Invalid experience level. Must be one of: entry, junior, mid, senior, lead, executive"
 	case "application_status":
-		return "Invalid application status. Must be one of: pending, reviewed, accepted, rejected"
+		return "Invalid application status. Must be one of: pending, reviewed, interviewed, accepted, rejected"
 	case "url":
 		return "Invalid URL format"
 	case "salary_range":